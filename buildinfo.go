@@ -2,19 +2,31 @@ package buildinfo
 
 import (
 	"encoding/json"
-	"fmt"
 	"runtime"
+	"sort"
+	"time"
 )
 
 const (
 	infoConcat = "; "
-	// infoFmt contains the format used by BuildInfo.Print
-	infoFmt = `%s, version %s (branch: %s, revision: %s)
-  build user:       %s
-  build host:       %s
-  build date:       %s
-  go version:       %s
-  platform:         %s`
+)
+
+// Mode is a set of flags controlling the behaviour of ParseMode
+type Mode uint
+
+const (
+	// RuntimeFallback populates fields which have been left at their
+	// default value with the information the Golang toolchain embedded
+	// into the binary (see runtime/debug.ReadBuildInfo). The main module
+	// version provides the Version, the vcs.revision setting the Revision,
+	// the vcs.modified setting the Dirty flag and the vcs.time setting
+	// the CommitDate and Date.
+	RuntimeFallback Mode = 1 << iota
+	// Strict rejects input containing fields unknown to this library
+	// version, as well as input written using a newer SchemaVersion.
+	// Without this flag, such fields are retained and written back
+	// by BuildInfo.JSON.
+	Strict
 )
 
 var (
@@ -27,6 +39,20 @@ var (
 type BuildInfo struct {
 	*VersionInfo
 	*EnvironmentInfo
+
+	// Extras contains arbitrary user-defined metadata
+	Extras map[string]string `json:"extras,omitempty"`
+
+	// Runtime is an optional description of the running binary.
+	// It is not part of the embedded information, but rather
+	// attached on demand (see WithRuntime).
+	Runtime *RuntimeInfo `json:"runtime,omitempty"`
+
+	// schema is the version of the data format the
+	// instance has been parsed from (see SchemaVersion)
+	schema int
+	// unknown contains parsed fields unknown to this library version
+	unknown map[string]json.RawMessage
 }
 
 // NewBuildInfo returns a BuildInfo instance using the provided values
@@ -46,13 +72,35 @@ func New() *BuildInfo {
 // a BuildInfo instance. An empty input or even nil
 // are considered valid values, and return a default
 // instance.
-func Parse(info []byte) (result *BuildInfo, err error) {
+func Parse(info []byte) (*BuildInfo, error) {
+	return ParseMode(info, 0)
+}
+
+// ParseMode behaves like Parse, with additional processing
+// of the input and the result according to the given mode.
+func ParseMode(info []byte, mode Mode) (result *BuildInfo, err error) {
 	result = New()
-	if info == nil || len(info) == 0 {
-		return
+	if mode&RuntimeFallback != 0 {
+		// the date is the only field without a static default
+		// value; its absence can only be detected by its zero value
+		result.Date = time.Time{}
+	}
+
+	if len(info) > 0 {
+		if err = json.Unmarshal(info, result); err != nil {
+			return
+		}
+	}
+
+	if mode&Strict != 0 {
+		if err = result.checkStrict(); err != nil {
+			return
+		}
 	}
 
-	err = json.Unmarshal(info, result)
+	if mode&RuntimeFallback != 0 {
+		fallbackRuntime(result)
+	}
 
 	return
 }
@@ -78,9 +126,32 @@ func MustParse(info []byte) *BuildInfo {
 	return result
 }
 
+// MustParseMode calls ParseMode and panics on any error
+func MustParseMode(info []byte, mode Mode) *BuildInfo {
+	result, err := ParseMode(info, mode)
+	if err != nil {
+		panic(err)
+	}
+
+	return result
+}
+
 // String returns the Version- and Environment information concatenated
 func (i *BuildInfo) String() string {
-	return i.VersionInfo.String() + infoConcat + i.EnvironmentInfo.String()
+	if i == nil {
+		return ""
+	}
+
+	v := i.VersionInfo
+	if v == nil {
+		v = &VersionInfo{}
+	}
+	e := i.EnvironmentInfo
+	if e == nil {
+		e = &EnvironmentInfo{}
+	}
+
+	return v.String() + infoConcat + e.String()
 }
 
 // Clone creates an independant copy of itself.
@@ -89,6 +160,7 @@ func (i *BuildInfo) Clone() *BuildInfo {
 		i2 *BuildInfo
 		v2 *VersionInfo
 		e2 *EnvironmentInfo
+		r2 *RuntimeInfo
 	)
 
 	if i.VersionInfo != nil {
@@ -99,9 +171,17 @@ func (i *BuildInfo) Clone() *BuildInfo {
 		e2 = i.EnvironmentInfo.Clone()
 	}
 
+	if i.Runtime != nil {
+		r2 = i.Runtime.Clone()
+	}
+
 	i2 = &BuildInfo{
 		VersionInfo:     v2,
 		EnvironmentInfo: e2,
+		Extras:          i.cloneExtras(),
+		Runtime:         r2,
+		schema:          i.schema,
+		unknown:         i.cloneUnknown(),
 	}
 
 	return i2
@@ -113,7 +193,56 @@ func (i *BuildInfo) Equal(o *BuildInfo) bool {
 		return i == nil && o == nil
 	}
 
-	return i.VersionInfo.Equal(o.VersionInfo) && i.EnvironmentInfo.Equal(o.EnvironmentInfo)
+	return i.VersionInfo.Equal(o.VersionInfo) && i.EnvironmentInfo.Equal(o.EnvironmentInfo) &&
+		i.equalExtras(o) && i.Runtime.Equal(o.Runtime)
+}
+
+func (i *BuildInfo) equalExtras(o *BuildInfo) bool {
+	if len(i.Extras) != len(o.Extras) {
+		return false
+	}
+
+	for k, v := range i.Extras {
+		if v2, ok := o.Extras[k]; !ok || v != v2 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (i *BuildInfo) cloneExtras() map[string]string {
+	if len(i.Extras) == 0 {
+		return nil
+	}
+
+	result := make(map[string]string, len(i.Extras))
+	for k, v := range i.Extras {
+		result[k] = v
+	}
+
+	return result
+}
+
+// ExtrasKeys returns the keys of the Extras in lexical order
+func (i *BuildInfo) ExtrasKeys() []string {
+	result := make([]string, 0, len(i.Extras))
+	for k := range i.Extras {
+		result = append(result, k)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// WithRuntime creates an independant copy of itself, with
+// the Runtime information describing the running binary.
+func (i *BuildInfo) WithRuntime() *BuildInfo {
+	i2 := i.Clone()
+	i2.Runtime = NewRuntimeInfo()
+
+	return i2
 }
 
 // JSON is a wrapper for json.Marshal using the instance as parameter
@@ -122,25 +251,12 @@ func (i *BuildInfo) JSON() ([]byte, error) {
 }
 
 // Print returns version- and environment information.
+// If Runtime information is available, it is used for the
+// toolchain information and the build settings are included
+// as well. Extras are appended in lexical order.
 func (i *BuildInfo) Print(program string) string {
-	v := i.VersionInfo
-	if v == nil {
-		v = NewVersionInfo()
-	}
-	e := i.EnvironmentInfo
-	if e == nil {
-		e = NewEnvironmentInfo()
-	}
-
-	return fmt.Sprintf(infoFmt,
-		program,
-		v.Version,
-		v.Branch,
-		v.ShortRevision(),
-		e.User,
-		e.Host,
-		e.Date,
-		GoVersion,
-		GoOS+"/"+GoArch,
-	)
+	// the built-in template is known to be valid
+	info, _ := i.PrintTemplate(program, printFormats[FormatDefault].tmpl)
+
+	return info
 }
@@ -15,6 +15,7 @@ func TestParse(t *testing.T) {
 		want      *BuildInfo
 	}
 
+	commitDate := time.Unix(6, 0)
 	testCases := map[string]testCase{
 		"nil": {
 			have: nil,
@@ -41,21 +42,33 @@ func TestParse(t *testing.T) {
 				"version":"1",
 				"revision":"2",
 				"branch":"3",
+				"dirty":true,
+				"commit_date":"1970-01-01T00:00:06Z",
+				"commit_author":"7",
+				"commits_since_tag":8,
 				"user":"4",
 				"host":"5",
-				"date":"1970-01-01T00:00:00.00000000Z"
+				"date":"1970-01-01T00:00:00.00000000Z",
+				"extras":{"channel":"stable"}
 			}`),
 			want: &BuildInfo{
 				VersionInfo: &VersionInfo{
-					Version:  "1",
-					Revision: "2",
-					Branch:   "3",
+					Version:         "1",
+					Revision:        "2",
+					Branch:          "3",
+					Dirty:           true,
+					CommitDate:      &commitDate,
+					CommitAuthor:    "7",
+					CommitsSinceTag: 8,
 				},
 				EnvironmentInfo: &EnvironmentInfo{
 					User: "4",
 					Host: "5",
 					Date: time.Unix(0, 0),
 				},
+				Extras: map[string]string{
+					"channel": "stable",
+				},
 			},
 		},
 		"malformed": {
@@ -76,6 +89,7 @@ func TestParse(t *testing.T) {
 			} else {
 				assert.Assert(t, err)
 				assert.Assert(t, tc.want.VersionInfo.Equal(got.VersionInfo), "want=%s; got=%s", tc.want, got)
+				assert.DeepEqual(t, tc.want.Extras, got.Extras)
 			}
 		})
 	}
@@ -93,12 +107,38 @@ func TestBuildInfoClone(t *testing.T) {
 		Date: time.Unix(3, 0),
 	}
 	have := NewBuildInfo(haveV, haveE)
+	have.Extras = map[string]string{
+		"channel": "stable",
+	}
+	have.Runtime = &RuntimeInfo{
+		GoVersion: "go1.21.5",
+		BuildTags: []string{"netgo"},
+	}
 	got := have.Clone()
+
+	assert.Assert(t, got.Equal(have))
+
+	got.Extras["channel"] = "beta"
+	got.Runtime.BuildTags[0] = "osusergo"
 	got.VersionInfo = nil
 	got.EnvironmentInfo = nil
+	got.Runtime = nil
 
 	assert.Assert(t, got.VersionInfo != have.VersionInfo)
 	assert.Assert(t, got.EnvironmentInfo != have.EnvironmentInfo)
+	assert.Assert(t, got.Runtime != have.Runtime)
+	assert.Equal(t, have.Runtime.BuildTags[0], "netgo")
+	assert.Equal(t, have.Extras["channel"], "stable")
+}
+
+func TestBuildInfoWithRuntime(t *testing.T) {
+	have := New()
+	got := have.WithRuntime()
+
+	assert.Assert(t, have.Runtime == nil)
+	assert.Assert(t, got.Runtime != nil)
+	assert.Assert(t, got.VersionInfo.Equal(have.VersionInfo))
+	assert.Equal(t, got.Runtime.GoVersion, GoVersion)
 }
 
 func TestBuildInfoEqual(t *testing.T) {
@@ -211,6 +251,37 @@ func TestBuildInfoEqual(t *testing.T) {
 				Date: time.Unix(0, 0),
 			}),
 		},
+		"extras": {
+			haveLeft: &BuildInfo{
+				VersionInfo: NewVersionInfo(),
+				Extras:      map[string]string{"channel": "stable"},
+			},
+			haveRight: &BuildInfo{
+				VersionInfo: NewVersionInfo(),
+				Extras:      map[string]string{"channel": "stable"},
+			},
+			want: true,
+		},
+		"extras empty": {
+			haveLeft: &BuildInfo{
+				VersionInfo: NewVersionInfo(),
+				Extras:      map[string]string{},
+			},
+			haveRight: &BuildInfo{
+				VersionInfo: NewVersionInfo(),
+			},
+			want: true,
+		},
+		"extras mismatch": {
+			haveLeft: &BuildInfo{
+				VersionInfo: NewVersionInfo(),
+				Extras:      map[string]string{"channel": "stable"},
+			},
+			haveRight: &BuildInfo{
+				VersionInfo: NewVersionInfo(),
+				Extras:      map[string]string{"sku": "stable"},
+			},
+		},
 		"environment mismatch": {
 			haveLeft: NewBuildInfo(&VersionInfo{
 				Version:  "1",
@@ -242,12 +313,23 @@ func TestBuildInfoEqual(t *testing.T) {
 	}
 }
 
+func mockGoRuntime(t *testing.T) {
+	goVersion, goOS, goArch := GoVersion, GoOS, GoArch
+	GoVersion, GoOS, GoArch = "go1.19.13", "linux", "amd64"
+
+	t.Cleanup(func() {
+		GoVersion, GoOS, GoArch = goVersion, goOS, goArch
+	})
+}
+
 func TestBuildInfoPrint(t *testing.T) {
 	type testCase struct {
 		have *BuildInfo
 		want string
 	}
 
+	mockGoRuntime(t)
+
 	testCases := map[string]testCase{
 		"nil": {
 			have: &BuildInfo{
@@ -275,6 +357,50 @@ func TestBuildInfoPrint(t *testing.T) {
 			},
 			want: "print_full.golden",
 		},
+		"runtime": {
+			have: &BuildInfo{
+				VersionInfo: &VersionInfo{
+					Version:  "1.2.3",
+					Revision: "deadbeef",
+					Branch:   "unstable",
+				},
+				EnvironmentInfo: &EnvironmentInfo{
+					User: "root",
+					Host: "example.com",
+					Date: time.Unix(123456790, 0),
+				},
+				Runtime: &RuntimeInfo{
+					GoVersion:  "go1.21.5",
+					GoOS:       "linux",
+					GoArch:     "arm",
+					ArchLevel:  "7",
+					BuildTags:  []string{"netgo", "osusergo"},
+					CGOEnabled: false,
+					TrimPath:   true,
+				},
+			},
+			want: "print_runtime.golden",
+		},
+		"extras": {
+			have: &BuildInfo{
+				VersionInfo: &VersionInfo{
+					Version:  "1.2.3",
+					Revision: "deadbeef",
+					Branch:   "unstable",
+				},
+				EnvironmentInfo: &EnvironmentInfo{
+					User: "root",
+					Host: "example.com",
+					Date: time.Unix(123456790, 0),
+				},
+				Extras: map[string]string{
+					"sku":      "enterprise",
+					"channel":  "stable",
+					"pipeline": "build 12345",
+				},
+			},
+			want: "print_extras.golden",
+		},
 	}
 
 	for ctx, tc := range testCases {
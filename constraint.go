@@ -0,0 +1,402 @@
+package buildinfo
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	// separator between alternative constraint ranges
+	constraintOr = "||"
+	// separator between the comparators of a constraint range
+	// (in addition to whitespace)
+	constraintAnd = ','
+)
+
+// ErrInvalidConstraint is the error used when parsing
+// a malformed version constraint expression.
+var ErrInvalidConstraint = errors.New("invalid version constraint")
+
+type operator int
+
+const (
+	opEQ operator = iota
+	opNE
+	opGT
+	opGE
+	opLT
+	opLE
+	// opOut matches versions outside of [version, upper);
+	// it has no textual representation and results from
+	// partial inequalities only
+	opOut
+)
+
+var operators = []struct {
+	text string
+	op   operator
+}{
+	// longer tokens must come first
+	{"==", opEQ},
+	{"!=", opNE},
+	{">=", opGE},
+	{"<=", opLE},
+	{"=", opEQ},
+	{">", opGT},
+	{"<", opLT},
+}
+
+type comparator struct {
+	op      operator
+	version *SemVer
+	upper   *SemVer
+}
+
+func (c *comparator) check(v *SemVer) bool {
+	r := v.Compare(c.version)
+
+	switch c.op {
+	case opEQ:
+		return r == 0
+	case opNE:
+		return r != 0
+	case opGT:
+		return r > 0
+	case opGE:
+		return r >= 0
+	case opLT:
+		return r < 0
+	case opLE:
+		return r <= 0
+	case opOut:
+		return r < 0 || v.Compare(c.upper) >= 0
+	default:
+		return false
+	}
+}
+
+// Constraint is a set of version ranges a version can be checked against.
+//
+// A constraint consists of one or more ranges separated by "||", of which
+// at least one must be satisfied. Each range is a list of comparators
+// separated by whitespace or commas, all of which must be satisfied.
+// The supported comparators are:
+//
+//	=1.2.3, ==1.2.3, 1.2.3  exact version
+//	!=1.2.3                 anything but the version
+//	>1.2.3, >=1.2.3         greater than (or equal to) the version
+//	<1.2.3, <=1.2.3         lower than (or equal to) the version
+//	~1.2.3                  patch level changes (>=1.2.3 <1.3.0)
+//	^1.2.3                  changes not modifying the left-most non-zero
+//	                        field (>=1.2.3 <2.0.0, ^0.2.3 is >=0.2.3 <0.3.0)
+//
+// Versions may be partial (1, 1.4) or use wildcards (1.x, 1.4.*),
+// in which case the missing fields match any value: 1.4 is
+// equivalent to >=1.4.0 <1.5.0, ~1 to >=1.0.0 <2.0.0, >1.4 to >=1.5.0,
+// !=1.4 to <1.4.0 || >=1.5.0, and a sole * matches every version.
+//
+// Versions with prerelease information only satisfy a range
+// if at least one of its comparators refers to a prerelease of the
+// very same major.minor.patch tuple. As such 1.3.0-rc.1 does not
+// satisfy >=1.2.0, but does satisfy >=1.3.0-beta.
+type Constraint struct {
+	text   string
+	ranges [][]*comparator
+}
+
+// ParseConstraint parses the given constraint expression.
+// The returned error wraps ErrInvalidConstraint in case of
+// syntax errors.
+func ParseConstraint(constraint string) (*Constraint, error) {
+	result := &Constraint{
+		text: strings.TrimSpace(constraint),
+	}
+
+	for _, expr := range strings.Split(constraint, constraintOr) {
+		r, err := parseRange(expr)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %v", ErrInvalidConstraint, constraint, err)
+		}
+
+		result.ranges = append(result.ranges, r)
+	}
+
+	return result, nil
+}
+
+// MustParseConstraint calls ParseConstraint and panics on any error
+func MustParseConstraint(constraint string) *Constraint {
+	result, err := ParseConstraint(constraint)
+	if err != nil {
+		panic(err)
+	}
+
+	return result
+}
+
+// String returns the constraint expression
+func (c *Constraint) String() string {
+	return c.text
+}
+
+// Check reports whether the given version satisfies the constraint
+func (c *Constraint) Check(v *SemVer) bool {
+	if v == nil {
+		return false
+	}
+
+	for _, r := range c.ranges {
+		if checkRange(r, v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Satisfies reports whether the Version value meets the given constraint.
+// Values which are not semantic versions never satisfy any constraint.
+func (i *VersionInfo) Satisfies(c *Constraint) bool {
+	v, err := i.SemVer()
+	if err != nil {
+		return false
+	}
+
+	return c.Check(v)
+}
+
+// SatisfiesConstraint parses the given constraint expression and
+// reports whether the Version value meets it.
+func (i *VersionInfo) SatisfiesConstraint(constraint string) (bool, error) {
+	c, err := ParseConstraint(constraint)
+	if err != nil {
+		return false, err
+	}
+
+	return i.Satisfies(c), nil
+}
+
+func checkRange(r []*comparator, v *SemVer) bool {
+	for _, c := range r {
+		if !c.check(v) {
+			return false
+		}
+	}
+
+	if !v.IsPrerelease() {
+		return true
+	}
+
+	for _, c := range r {
+		if c.version.IsPrerelease() &&
+			c.version.Major == v.Major &&
+			c.version.Minor == v.Minor &&
+			c.version.Patch == v.Patch {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseRange(expr string) ([]*comparator, error) {
+	terms := strings.FieldsFunc(expr, func(r rune) bool {
+		return r == constraintAnd || unicode.IsSpace(r)
+	})
+	if len(terms) == 0 {
+		return nil, errors.New("empty range")
+	}
+
+	result := make([]*comparator, 0, len(terms))
+	for i := 0; i < len(terms); i++ {
+		term := terms[i]
+		// allow whitespace between operator and version, e.g. ">= 1.2.3"
+		if strings.Trim(term, "=!<>~^") == "" && i+1 < len(terms) {
+			i++
+			term += terms[i]
+		}
+
+		c, err := parseTerm(term)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, c...)
+	}
+
+	return result, nil
+}
+
+func parseTerm(term string) ([]*comparator, error) {
+	switch {
+	case strings.HasPrefix(term, "~"):
+		return parseTilde(term[1:])
+	case strings.HasPrefix(term, "^"):
+		return parseCaret(term[1:])
+	}
+
+	op := opEQ
+	for _, o := range operators {
+		if strings.HasPrefix(term, o.text) {
+			op = o.op
+			term = term[len(o.text):]
+			break
+		}
+	}
+
+	p, err := parsePartial(term)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.fields == 0 {
+		// wildcard
+		switch op {
+		case opEQ, opGE, opLE:
+			return []*comparator{}, nil
+		default:
+			// nothing is lower, greater, or unequal to everything
+			return []*comparator{{op: opLT, version: &SemVer{}}}, nil
+		}
+	}
+
+	if p.fields == 3 {
+		return []*comparator{{op: op, version: p.version}}, nil
+	}
+
+	lower, upper := p.version, p.next()
+
+	switch op {
+	case opEQ:
+		return []*comparator{{op: opGE, version: lower}, {op: opLT, version: upper}}, nil
+	case opNE:
+		// !=1.4 means <1.4.0 || >=1.5.0
+		return []*comparator{{op: opOut, version: lower, upper: upper}}, nil
+	case opGT, opLE:
+		// >1.4 means >=1.5.0, <=1.4 means <1.5.0
+		if op == opGT {
+			op = opGE
+		} else {
+			op = opLT
+		}
+
+		return []*comparator{{op: op, version: upper}}, nil
+	default:
+		return []*comparator{{op: op, version: lower}}, nil
+	}
+}
+
+func parseTilde(term string) ([]*comparator, error) {
+	p, err := parsePartial(term)
+	if err != nil {
+		return nil, err
+	} else if p.fields == 0 {
+		return []*comparator{}, nil
+	}
+
+	upper := &SemVer{Major: p.version.Major + 1}
+	if p.fields > 1 {
+		upper = &SemVer{Major: p.version.Major, Minor: p.version.Minor + 1}
+	}
+
+	return []*comparator{{op: opGE, version: p.version}, {op: opLT, version: upper}}, nil
+}
+
+func parseCaret(term string) ([]*comparator, error) {
+	p, err := parsePartial(term)
+	if err != nil {
+		return nil, err
+	} else if p.fields == 0 {
+		return []*comparator{}, nil
+	}
+
+	v := p.version
+	var upper *SemVer
+
+	switch {
+	case v.Major > 0 || p.fields == 1:
+		upper = &SemVer{Major: v.Major + 1}
+	case v.Minor > 0 || p.fields == 2:
+		upper = &SemVer{Minor: v.Minor + 1}
+	default:
+		upper = &SemVer{Patch: v.Patch + 1}
+	}
+
+	return []*comparator{{op: opGE, version: v}, {op: opLT, version: upper}}, nil
+}
+
+// partialVersion is a version which might be missing
+// some of its fields
+type partialVersion struct {
+	version *SemVer
+	fields  int
+}
+
+// next returns the lowest version not matching the partial version
+func (p *partialVersion) next() *SemVer {
+	switch p.fields {
+	case 1:
+		return &SemVer{Major: p.version.Major + 1}
+	case 2:
+		return &SemVer{Major: p.version.Major, Minor: p.version.Minor + 1}
+	default:
+		return p.version
+	}
+}
+
+func parsePartial(term string) (*partialVersion, error) {
+	if term == "" {
+		return nil, errors.New("missing version")
+	}
+
+	core := TrimVersionPrefix(term)
+	if i := strings.IndexAny(core, prereleaseConcat+buildConcat); i >= 0 {
+		core = core[:i]
+	}
+
+	parts := strings.Split(core, identifierConcat)
+	if len(parts) > 3 {
+		return nil, fmt.Errorf("malformed version %q", term)
+	}
+
+	fields := 0
+	for _, p := range parts {
+		if isWildcard(p) {
+			break
+		}
+
+		fields++
+	}
+
+	for _, p := range parts[fields:] {
+		if !isWildcard(p) {
+			return nil, fmt.Errorf("version %q has fields after a wildcard", term)
+		}
+	}
+
+	if fields < 3 {
+		if core != TrimVersionPrefix(term) {
+			return nil, fmt.Errorf("partial version %q must not contain prerelease or build information", term)
+		}
+
+		parts = append(parts[:fields], "0", "0", "0")[:3]
+	}
+
+	v, err := ParseSemVer(strings.Join(parts, identifierConcat) + TrimVersionPrefix(term)[len(core):])
+	if err != nil {
+		return nil, err
+	}
+
+	result := &partialVersion{
+		version: v,
+		fields:  fields,
+	}
+
+	return result, nil
+}
+
+func isWildcard(s string) bool {
+	return s == "*" || s == "x" || s == "X"
+}
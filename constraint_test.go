@@ -0,0 +1,313 @@
+package buildinfo
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestParseConstraint(t *testing.T) {
+	type testCase struct {
+		have      string
+		wantError bool
+	}
+
+	testCases := map[string]testCase{
+		"empty": {
+			have:      "",
+			wantError: true,
+		},
+		"empty alternative": {
+			have:      ">=1.0.0 ||",
+			wantError: true,
+		},
+		"missing version": {
+			have:      ">=",
+			wantError: true,
+		},
+		"malformed version": {
+			have:      "1.2.3.4",
+			wantError: true,
+		},
+		"fields after wildcard": {
+			have:      "1.x.3",
+			wantError: true,
+		},
+		"partial prerelease": {
+			have:      "1.2-rc.1",
+			wantError: true,
+		},
+		"range": {
+			have: ">=1.2.0 <2.0.0",
+		},
+		"comma range": {
+			have: ">=1.2.0, <2.0.0",
+		},
+		"spaced operator": {
+			have: ">= 1.2.0",
+		},
+		"alternatives": {
+			have: "~1.4 || ^2",
+		},
+		"wildcard": {
+			have: "*",
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			got, err := ParseConstraint(tc.have)
+
+			if tc.wantError {
+				assert.ErrorIs(t, err, ErrInvalidConstraint)
+			} else {
+				assert.Assert(t, err)
+				assert.Equal(t, got.String(), tc.have)
+			}
+		})
+	}
+}
+
+func TestConstraintCheck(t *testing.T) {
+	type testCase struct {
+		haveConstraint string
+		haveVersion    string
+		want           bool
+	}
+
+	testCases := map[string]testCase{
+		"exact": {
+			haveConstraint: "1.2.3",
+			haveVersion:    "1.2.3",
+			want:           true,
+		},
+		"exact mismatch": {
+			haveConstraint: "=1.2.3",
+			haveVersion:    "1.2.4",
+		},
+		"exact prefix": {
+			haveConstraint: "==v1.2.3",
+			haveVersion:    "v1.2.3",
+			want:           true,
+		},
+		"not equal": {
+			haveConstraint: "!=1.2.3",
+			haveVersion:    "1.2.4",
+			want:           true,
+		},
+		"partial not equal": {
+			haveConstraint: "!=1.4",
+			haveVersion:    "1.4.3",
+		},
+		"partial not equal lower bound": {
+			haveConstraint: "!=1.4",
+			haveVersion:    "1.4.0",
+		},
+		"partial not equal below": {
+			haveConstraint: "!=1.4",
+			haveVersion:    "1.3.9",
+			want:           true,
+		},
+		"partial not equal above": {
+			haveConstraint: "!=1.4",
+			haveVersion:    "1.5.0",
+			want:           true,
+		},
+		"partial not equal major": {
+			haveConstraint: "!=1",
+			haveVersion:    "1.9.9",
+		},
+		"partial not equal in range": {
+			haveConstraint: ">=1.0.0 !=1.4",
+			haveVersion:    "1.6.0",
+			want:           true,
+		},
+		"partial exact": {
+			haveConstraint: "1.4",
+			haveVersion:    "1.4.9",
+			want:           true,
+		},
+		"partial exact mismatch": {
+			haveConstraint: "1.4",
+			haveVersion:    "1.5.0",
+		},
+		"wildcard exact": {
+			haveConstraint: "1.x",
+			haveVersion:    "1.99.0",
+			want:           true,
+		},
+		"range lower": {
+			haveConstraint: ">=1.2.0 <2.0.0",
+			haveVersion:    "1.2.0",
+			want:           true,
+		},
+		"range upper": {
+			haveConstraint: ">=1.2.0 <2.0.0",
+			haveVersion:    "2.0.0",
+		},
+		"range below": {
+			haveConstraint: ">=1.2.0, <2.0.0",
+			haveVersion:    "1.1.9",
+		},
+		"partial greater": {
+			haveConstraint: ">1.4",
+			haveVersion:    "1.4.9",
+		},
+		"partial greater match": {
+			haveConstraint: ">1.4",
+			haveVersion:    "1.5.0",
+			want:           true,
+		},
+		"partial lower equal": {
+			haveConstraint: "<=1.4",
+			haveVersion:    "1.4.9",
+			want:           true,
+		},
+		"partial lower": {
+			haveConstraint: "<1.4",
+			haveVersion:    "1.4.0",
+		},
+		"tilde minor": {
+			haveConstraint: "~1.4",
+			haveVersion:    "1.4.7",
+			want:           true,
+		},
+		"tilde minor exceeded": {
+			haveConstraint: "~1.4",
+			haveVersion:    "1.5.0",
+		},
+		"tilde patch": {
+			haveConstraint: "~1.4.2",
+			haveVersion:    "1.4.1",
+		},
+		"tilde major": {
+			haveConstraint: "~1",
+			haveVersion:    "1.9.0",
+			want:           true,
+		},
+		"caret major": {
+			haveConstraint: "^2",
+			haveVersion:    "2.7.1",
+			want:           true,
+		},
+		"caret major exceeded": {
+			haveConstraint: "^2",
+			haveVersion:    "3.0.0",
+		},
+		"caret zero minor": {
+			haveConstraint: "^0.2.3",
+			haveVersion:    "0.3.0",
+		},
+		"caret zero minor match": {
+			haveConstraint: "^0.2.3",
+			haveVersion:    "0.2.9",
+			want:           true,
+		},
+		"caret zero patch": {
+			haveConstraint: "^0.0.3",
+			haveVersion:    "0.0.4",
+		},
+		"alternatives": {
+			haveConstraint: "~1.4 || ^2",
+			haveVersion:    "2.1.0",
+			want:           true,
+		},
+		"alternatives mismatch": {
+			haveConstraint: "~1.4 || ^2",
+			haveVersion:    "1.5.0",
+		},
+		"any": {
+			haveConstraint: "*",
+			haveVersion:    DefaultVersion,
+			want:           true,
+		},
+		"prerelease excluded": {
+			haveConstraint: ">=1.2.0",
+			haveVersion:    "1.3.0-rc.1",
+		},
+		"prerelease same tuple": {
+			haveConstraint: ">=1.3.0-beta",
+			haveVersion:    "1.3.0-rc.1",
+			want:           true,
+		},
+		"prerelease other tuple": {
+			haveConstraint: ">=1.3.0-beta",
+			haveVersion:    "1.4.0-rc.1",
+		},
+		"prerelease lower": {
+			haveConstraint: ">=1.3.0-rc.2",
+			haveVersion:    "1.3.0-rc.1",
+		},
+		"prerelease below release": {
+			haveConstraint: "<2.0.0",
+			haveVersion:    "2.0.0-rc.1",
+		},
+		"prerelease caret": {
+			haveConstraint: "^1.2.3-beta.2",
+			haveVersion:    "1.2.3-beta.4",
+			want:           true,
+		},
+		"build metadata ignored": {
+			haveConstraint: "=1.2.3",
+			haveVersion:    "1.2.3+build.7",
+			want:           true,
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			c := MustParseConstraint(tc.haveConstraint)
+			got := c.Check(MustParseSemVer(tc.haveVersion))
+
+			assert.Equal(t, got, tc.want, "constraint=%s; version=%s", tc.haveConstraint, tc.haveVersion)
+		})
+	}
+}
+
+func TestVersionInfoSatisfiesConstraint(t *testing.T) {
+	type testCase struct {
+		have           *VersionInfo
+		haveConstraint string
+		wantError      bool
+		want           bool
+	}
+
+	testCases := map[string]testCase{
+		"default": {
+			have:           NewVersionInfo(),
+			haveConstraint: ">=1.0.0",
+		},
+		"default wildcard": {
+			have:           NewVersionInfo(),
+			haveConstraint: "*",
+			want:           true,
+		},
+		"opaque": {
+			have:           &VersionInfo{Version: "1.2"},
+			haveConstraint: "*",
+		},
+		"match": {
+			have:           &VersionInfo{Version: "v1.4.2"},
+			haveConstraint: ">=1.2.0 <2.0.0",
+			want:           true,
+		},
+		"malformed": {
+			have:           &VersionInfo{Version: "1.4.2"},
+			haveConstraint: "=>1.0.0",
+			wantError:      true,
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			got, err := tc.have.SatisfiesConstraint(tc.haveConstraint)
+
+			if tc.wantError {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+				assert.Equal(t, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,338 @@
+package buildinfo
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// diffFmt contains the format used by Diff.String for each Change
+	diffFmt = "%s: %s -> %s"
+	// diffVersionFmt contains the format used by Diff.String
+	// for the version Change with a known VersionDelta
+	diffVersionFmt = diffFmt + " (%s %s)"
+)
+
+// Field identifies a compared value of BuildInfo.
+// The names match the keys of the text encoding.
+type Field string
+
+// Fields compared by BuildInfo.Diff
+const (
+	FieldVersion         Field = textVersion
+	FieldRevision        Field = textRevision
+	FieldBranch          Field = textBranch
+	FieldDirty           Field = textDirty
+	FieldCommitDate      Field = textCommitDate
+	FieldCommitAuthor    Field = textCommitAuthor
+	FieldCommitsSinceTag Field = textCommitsSinceTag
+	FieldUser            Field = textUser
+	FieldHost            Field = textHost
+	FieldDate            Field = textDate
+)
+
+// ExtraField returns the Field identifying the Extras entry with the given key
+func ExtraField(key string) Field {
+	return Field(textExtraPrefix + key)
+}
+
+// ExtraKey returns the Extras key identified by the Field,
+// or false if the field does not describe an Extras entry.
+func (f Field) ExtraKey() (string, bool) {
+	if !strings.HasPrefix(string(f), textExtraPrefix) {
+		return "", false
+	}
+
+	return strings.TrimPrefix(string(f), textExtraPrefix), true
+}
+
+// String returns the field name
+func (f Field) String() string {
+	return string(f)
+}
+
+// VersionDelta classifies the difference between two versions
+// according to the Semantic Versioning specification.
+type VersionDelta uint8
+
+const (
+	// VersionSame indicates identical versions
+	VersionSame VersionDelta = iota
+	// VersionBuild indicates versions differing only in their build metadata
+	VersionBuild
+	// VersionPrerelease indicates versions differing in their prerelease identifiers
+	VersionPrerelease
+	// VersionPatch indicates versions differing in their patch number
+	VersionPatch
+	// VersionMinor indicates versions differing in their minor number
+	VersionMinor
+	// VersionMajor indicates versions differing in their major number
+	VersionMajor
+	// VersionIncomparable indicates that at least one
+	// of the versions is not a semantic version
+	VersionIncomparable
+)
+
+var versionDeltaNames = []string{
+	VersionSame:         "same",
+	VersionBuild:        "build",
+	VersionPrerelease:   "prerelease",
+	VersionPatch:        "patch",
+	VersionMinor:        "minor",
+	VersionMajor:        "major",
+	VersionIncomparable: "incomparable",
+}
+
+// NewVersionDelta classifies the difference between the given versions.
+func NewVersionDelta(a, b *VersionInfo) VersionDelta {
+	if a.Version == b.Version {
+		return VersionSame
+	}
+
+	s1, err1 := a.SemVer()
+	s2, err2 := b.SemVer()
+	if err1 != nil || err2 != nil {
+		return VersionIncomparable
+	}
+
+	switch {
+	case s1.Major != s2.Major:
+		return VersionMajor
+	case s1.Minor != s2.Minor:
+		return VersionMinor
+	case s1.Patch != s2.Patch:
+		return VersionPatch
+	case s1.Prerelease != s2.Prerelease:
+		return VersionPrerelease
+	case s1.Build != s2.Build:
+		return VersionBuild
+	}
+
+	return VersionSame
+}
+
+// String returns the name of the delta
+func (d VersionDelta) String() string {
+	if int(d) < len(versionDeltaNames) {
+		return versionDeltaNames[d]
+	}
+
+	return "VersionDelta(" + strconv.Itoa(int(d)) + ")"
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (d VersionDelta) MarshalText() ([]byte, error) {
+	if int(d) >= len(versionDeltaNames) {
+		return nil, fmt.Errorf("invalid version delta %d", d)
+	}
+
+	return []byte(versionDeltaNames[d]), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (d *VersionDelta) UnmarshalText(text []byte) error {
+	for n, name := range versionDeltaNames {
+		if name == string(text) {
+			*d = VersionDelta(n)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid version delta %q", text)
+}
+
+// Change describes the difference of a single Field.
+// The values use the same representation as the text encoding.
+type Change struct {
+	Field Field  `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// String returns the field name and both values
+func (c *Change) String() string {
+	return fmt.Sprintf(diffFmt, c.Field, diffValue(c.From), diffValue(c.To))
+}
+
+// Diff is the result of comparing two BuildInfo instances
+type Diff struct {
+	// Changes contains the differing fields in a stable order
+	Changes []*Change `json:"changes"`
+	// Version classifies the version difference
+	Version VersionDelta `json:"version"`
+	// Direction is 1 for a version upgrade, -1
+	// for a downgrade and 0 for equal versions.
+	Direction int `json:"direction"`
+}
+
+// Diff compares the instance with the given one, which is considered
+// to be the newer one. Missing version- or environment information
+// is treated like empty values. The Runtime information is not part
+// of the comparison.
+func (i *BuildInfo) Diff(o *BuildInfo) *Diff {
+	v1, e1, x1 := i.diffParts()
+	v2, e2, x2 := o.diffParts()
+
+	result := &Diff{
+		Changes:   []*Change{},
+		Version:   NewVersionDelta(v1, v2),
+		Direction: v2.Compare(v1),
+	}
+
+	result.add(FieldVersion, v1.Version, v2.Version)
+	result.add(FieldRevision, v1.Revision, v2.Revision)
+	result.add(FieldBranch, v1.Branch, v2.Branch)
+	result.add(FieldDirty, strconv.FormatBool(v1.Dirty), strconv.FormatBool(v2.Dirty))
+	result.addTime(FieldCommitDate, diffTimePtr(v1.CommitDate), diffTimePtr(v2.CommitDate))
+	result.add(FieldCommitAuthor, v1.CommitAuthor, v2.CommitAuthor)
+	result.add(FieldCommitsSinceTag, strconv.FormatUint(uint64(v1.CommitsSinceTag), 10),
+		strconv.FormatUint(uint64(v2.CommitsSinceTag), 10))
+	result.add(FieldUser, e1.User, e2.User)
+	result.add(FieldHost, e1.Host, e2.Host)
+	result.addTime(FieldDate, e1.Date, e2.Date)
+
+	keys := map[string]struct{}{}
+	for k := range x1 {
+		keys[k] = struct{}{}
+	}
+	for k := range x2 {
+		keys[k] = struct{}{}
+	}
+
+	extras := make([]string, 0, len(keys))
+	for k := range keys {
+		extras = append(extras, k)
+	}
+	sort.Strings(extras)
+
+	for _, k := range extras {
+		result.add(ExtraField(k), x1[k], x2[k])
+	}
+
+	return result
+}
+
+func (i *BuildInfo) diffParts() (*VersionInfo, *EnvironmentInfo, map[string]string) {
+	v, e := &VersionInfo{}, &EnvironmentInfo{}
+	if i == nil {
+		return v, e, nil
+	}
+
+	if i.VersionInfo != nil {
+		v = i.VersionInfo
+	}
+
+	if i.EnvironmentInfo != nil {
+		e = i.EnvironmentInfo
+	}
+
+	return v, e, i.Extras
+}
+
+func (d *Diff) add(f Field, from, to string) {
+	if from == to {
+		return
+	}
+
+	d.Changes = append(d.Changes, &Change{
+		Field: f,
+		From:  from,
+		To:    to,
+	})
+}
+
+// addTime records a Change if the given timestamps describe
+// different instants, regardless of their location
+func (d *Diff) addTime(f Field, from, to time.Time) {
+	if from.Equal(to) {
+		return
+	}
+
+	d.add(f, diffTime(from), diffTime(to))
+}
+
+// Empty reports whether no differences have been found
+func (d *Diff) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+// Get returns the Change of the given Field,
+// or nil if the field did not change.
+func (d *Diff) Get(f Field) *Change {
+	for _, c := range d.Changes {
+		if c.Field == f {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// Has reports whether the given Field changed
+func (d *Diff) Has(f Field) bool {
+	return d.Get(f) != nil
+}
+
+// Upgrade reports whether the version increased
+func (d *Diff) Upgrade() bool {
+	return d.Direction > 0
+}
+
+// Downgrade reports whether the version decreased
+func (d *Diff) Downgrade() bool {
+	return d.Direction < 0
+}
+
+// Rebuild reports whether the same revision has
+// been built again, e.g. at another time or host.
+func (d *Diff) Rebuild() bool {
+	return !d.Empty() && !d.Has(FieldVersion) && !d.Has(FieldRevision) &&
+		!d.Has(FieldBranch) && !d.Has(FieldDirty)
+}
+
+// String returns one line per Change. The version
+// change is annotated with its VersionDelta.
+func (d *Diff) String() string {
+	lines := make([]string, 0, len(d.Changes))
+	for _, c := range d.Changes {
+		if c.Field == FieldVersion && d.Version != VersionSame && d.Version != VersionIncomparable {
+			direction := "change"
+			if d.Upgrade() {
+				direction = "upgrade"
+			} else if d.Downgrade() {
+				direction = "downgrade"
+			}
+
+			lines = append(lines, fmt.Sprintf(diffVersionFmt, c.Field,
+				diffValue(c.From), diffValue(c.To), d.Version, direction))
+		} else {
+			lines = append(lines, c.String())
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// diffValue quotes the given value if necessary
+func diffValue(v string) string {
+	return string(appendTextToken(nil, v))
+}
+
+func diffTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339Nano)
+}
+
+func diffTimePtr(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+
+	return *t
+}
@@ -0,0 +1,227 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestNewVersionDelta(t *testing.T) {
+	type testCase struct {
+		haveA, haveB string
+		want         VersionDelta
+	}
+
+	testCases := map[string]testCase{
+		"same":         {haveA: "1.2.3", haveB: "1.2.3", want: VersionSame},
+		"prefix":       {haveA: "v1.2.3", haveB: "1.2.3", want: VersionSame},
+		"build":        {haveA: "1.2.3+1", haveB: "1.2.3+2", want: VersionBuild},
+		"prerelease":   {haveA: "1.2.3-rc.1", haveB: "1.2.3", want: VersionPrerelease},
+		"patch":        {haveA: "1.2.3", haveB: "1.2.4", want: VersionPatch},
+		"minor":        {haveA: "1.2.3", haveB: "1.3.0", want: VersionMinor},
+		"major":        {haveA: "2.0.0", haveB: "1.9.9", want: VersionMajor},
+		"incomparable": {haveA: "1.2.3", haveB: "latest", want: VersionIncomparable},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			got := NewVersionDelta(&VersionInfo{Version: tc.haveA}, &VersionInfo{Version: tc.haveB})
+			assert.Equal(t, got, tc.want)
+		})
+	}
+}
+
+func TestVersionDeltaText(t *testing.T) {
+	for d := VersionSame; d <= VersionIncomparable; d++ {
+		text, err := d.MarshalText()
+		assert.NilError(t, err)
+
+		var got VersionDelta
+		assert.NilError(t, got.UnmarshalText(text))
+		assert.Equal(t, got, d)
+	}
+
+	_, err := VersionDelta(255).MarshalText()
+	assert.Assert(t, err != nil)
+
+	var got VersionDelta
+	assert.Assert(t, got.UnmarshalText([]byte("huge")) != nil)
+}
+
+func TestBuildInfoDiff(t *testing.T) {
+	type testCase struct {
+		haveA, haveB  *BuildInfo
+		wantChanges   []*Change
+		wantVersion   VersionDelta
+		wantDirection int
+		wantRebuild   bool
+		wantString    string
+	}
+
+	date := time.Unix(1700000000, 0).UTC()
+	base := &BuildInfo{
+		VersionInfo: &VersionInfo{
+			Version:  "1.2.3",
+			Revision: "deadbeef",
+			Branch:   "main",
+		},
+		EnvironmentInfo: &EnvironmentInfo{
+			User: "ci",
+			Host: "runner-1",
+			Date: date,
+		},
+		Extras: map[string]string{
+			"channel": "stable",
+		},
+	}
+
+	withVersion := func(v string) *BuildInfo {
+		i := base.Clone()
+		i.Version = v
+		i.Revision = "cafebabe"
+		return i
+	}
+
+	rebuild := base.Clone()
+	rebuild.Host = "runner-2"
+	rebuild.Date = date.Add(time.Hour)
+
+	// same instants in different locations
+	commitDate := date.Add(-time.Hour)
+	localCommitDate := commitDate.In(time.FixedZone("EST", -5*3600))
+	relocated := base.Clone()
+	relocated.CommitDate = &commitDate
+	relocated.Date = date.In(time.FixedZone("CET", 3600))
+
+	relocatedCommit := relocated.Clone()
+	relocatedCommit.CommitDate = &localCommitDate
+
+	switched := base.Clone()
+	switched.Branch = "release/1.2"
+	switched.Extras = map[string]string{"team": "platform"}
+
+	testCases := map[string]testCase{
+		"equal": {
+			haveA:       base,
+			haveB:       base.Clone(),
+			wantChanges: []*Change{},
+			wantString:  "",
+		},
+		"same instant": {
+			haveA:       relocated,
+			haveB:       relocatedCommit,
+			wantChanges: []*Change{},
+			wantString:  "",
+		},
+		"relocated": {
+			haveA:       base,
+			haveB:       relocated,
+			wantChanges: []*Change{{Field: FieldCommitDate, From: "", To: "2023-11-14T21:13:20Z"}},
+			wantRebuild: true,
+			wantString:  "commit_date: \"\" -> 2023-11-14T21:13:20Z",
+		},
+		"minor upgrade": {
+			haveA: base,
+			haveB: withVersion("1.3.0"),
+			wantChanges: []*Change{
+				{Field: FieldVersion, From: "1.2.3", To: "1.3.0"},
+				{Field: FieldRevision, From: "deadbeef", To: "cafebabe"},
+			},
+			wantVersion:   VersionMinor,
+			wantDirection: 1,
+			wantString: "version: 1.2.3 -> 1.3.0 (minor upgrade)\n" +
+				"revision: deadbeef -> cafebabe",
+		},
+		"major downgrade": {
+			haveA: withVersion("2.0.0"),
+			haveB: base,
+			wantChanges: []*Change{
+				{Field: FieldVersion, From: "2.0.0", To: "1.2.3"},
+				{Field: FieldRevision, From: "cafebabe", To: "deadbeef"},
+			},
+			wantVersion:   VersionMajor,
+			wantDirection: -1,
+			wantString: "version: 2.0.0 -> 1.2.3 (major downgrade)\n" +
+				"revision: cafebabe -> deadbeef",
+		},
+		"rebuild": {
+			haveA: base,
+			haveB: rebuild,
+			wantChanges: []*Change{
+				{Field: FieldHost, From: "runner-1", To: "runner-2"},
+				{Field: FieldDate, From: "2023-11-14T22:13:20Z", To: "2023-11-14T23:13:20Z"},
+			},
+			wantRebuild: true,
+			wantString: "host: runner-1 -> runner-2\n" +
+				"date: 2023-11-14T22:13:20Z -> 2023-11-14T23:13:20Z",
+		},
+		"branch switch": {
+			haveA: base,
+			haveB: switched,
+			wantChanges: []*Change{
+				{Field: FieldBranch, From: "main", To: "release/1.2"},
+				{Field: ExtraField("channel"), From: "stable", To: ""},
+				{Field: ExtraField("team"), From: "", To: "platform"},
+			},
+			wantString: "branch: main -> release/1.2\n" +
+				`extra.channel: stable -> ""` + "\n" +
+				`extra.team: "" -> platform`,
+		},
+		"nil": {
+			haveA: nil,
+			haveB: &BuildInfo{
+				VersionInfo: &VersionInfo{Version: "1.0.0", Dirty: true},
+			},
+			wantChanges: []*Change{
+				{Field: FieldVersion, From: "", To: "1.0.0"},
+				{Field: FieldDirty, From: "false", To: "true"},
+			},
+			wantVersion:   VersionIncomparable,
+			wantDirection: 1,
+			wantString: `version: "" -> 1.0.0` + "\n" +
+				"dirty: false -> true",
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			got := tc.haveA.Diff(tc.haveB)
+
+			assert.DeepEqual(t, got.Changes, tc.wantChanges)
+			assert.Equal(t, got.Version, tc.wantVersion)
+			assert.Equal(t, got.Direction, tc.wantDirection)
+			assert.Equal(t, got.Empty(), len(tc.wantChanges) == 0)
+			assert.Equal(t, got.Rebuild(), tc.wantRebuild)
+			assert.Equal(t, got.String(), tc.wantString)
+
+			for _, c := range tc.wantChanges {
+				assert.Assert(t, got.Has(c.Field))
+			}
+		})
+	}
+}
+
+func TestDiffJSON(t *testing.T) {
+	a := &BuildInfo{VersionInfo: &VersionInfo{Version: "1.2.3"}}
+	b := &BuildInfo{VersionInfo: &VersionInfo{Version: "1.2.4"}}
+
+	got, err := json.Marshal(a.Diff(b))
+	assert.NilError(t, err)
+	assert.Equal(t, string(got),
+		`{"changes":[{"field":"version","from":"1.2.3","to":"1.2.4"}],"version":"patch","direction":1}`)
+
+	parsed := &Diff{}
+	assert.NilError(t, json.Unmarshal(got, parsed))
+	assert.DeepEqual(t, parsed, a.Diff(b))
+}
+
+func TestFieldExtraKey(t *testing.T) {
+	k, ok := ExtraField("channel").ExtraKey()
+	assert.Assert(t, ok)
+	assert.Equal(t, k, "channel")
+
+	_, ok = FieldVersion.ExtraKey()
+	assert.Assert(t, !ok)
+}
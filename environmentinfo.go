@@ -1,6 +1,7 @@
 package buildinfo
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 )
@@ -10,6 +11,9 @@ const (
 	DefaultUser = "unknown"
 	// default value for EnvironmentInfo.Host
 	DefaultHost = "localhost"
+	// value of EnvironmentInfo.User and EnvironmentInfo.Host
+	// for reproducible builds (see SOURCE_DATE_EPOCH)
+	ReproduciblePlaceholder = "reproducible"
 )
 
 const (
@@ -37,6 +41,22 @@ func (i *EnvironmentInfo) String() string {
 	return fmt.Sprintf("(user=%s, host=%s, date=%s)", i.User, i.Host, i.Date)
 }
 
+// environmentInfo has the same fields as EnvironmentInfo,
+// without the custom (un)marshalling
+type environmentInfo EnvironmentInfo
+
+// MarshalJSON implements the json.Marshaler interface. It takes
+// precedence over MarshalText to retain the object representation.
+func (i *EnvironmentInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal((*environmentInfo)(i))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. It takes
+// precedence over UnmarshalText to retain the object representation.
+func (i *EnvironmentInfo) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*environmentInfo)(i))
+}
+
 // Clone creates an independant copy of itself.
 func (i *EnvironmentInfo) Clone() *EnvironmentInfo {
 	i2 := *i
@@ -58,3 +78,11 @@ func (i *EnvironmentInfo) Equal(o *EnvironmentInfo) bool {
 func (i *EnvironmentInfo) UserHost() string {
 	return i.User + userConcat + i.Host
 }
+
+// Reproducible reports whether the information originates from a
+// reproducible build, i.e. User and Host are ReproduciblePlaceholder.
+// The Date of such builds is fixed (see SOURCE_DATE_EPOCH) and does
+// not reflect the time the binary has been built.
+func (i *EnvironmentInfo) Reproducible() bool {
+	return i.User == ReproduciblePlaceholder && i.Host == ReproduciblePlaceholder
+}
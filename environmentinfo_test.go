@@ -111,3 +111,36 @@ func TestEnvironmentInfoEqual(t *testing.T) {
 		})
 	}
 }
+
+func TestEnvironmentInfoReproducible(t *testing.T) {
+	type testCase struct {
+		have *EnvironmentInfo
+		want bool
+	}
+
+	testCases := map[string]testCase{
+		"default": {
+			have: NewEnvironmentInfo(),
+		},
+		"reproducible": {
+			have: &EnvironmentInfo{
+				User: ReproduciblePlaceholder,
+				Host: ReproduciblePlaceholder,
+				Date: time.Unix(0, 0),
+			},
+			want: true,
+		},
+		"partial": {
+			have: &EnvironmentInfo{
+				User: ReproduciblePlaceholder,
+				Host: "example.com",
+			},
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			assert.Equal(t, tc.have.Reproducible(), tc.want)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// Package expvarinfo publishes build information via the expvar package.
+package expvarinfo
+
+import (
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"sync"
+
+	"github.com/UiP9AV6Y/buildinfo"
+)
+
+// ErrDuplicate is the error used when publishing
+// a variable using a name which is already in use
+var ErrDuplicate = errors.New("variable already published")
+
+// publishMu serializes the lookup and publication of variables,
+// as expvar.Publish panics when reusing an existing name
+var publishMu sync.Mutex
+
+// Var returns a variable whose value is the JSON encoding of the given
+// build information, with the Runtime information describing the running
+// binary (see buildinfo.BuildInfo.WithRuntime). Unlike expvar.Func, the
+// value is encoded upfront, hence the error for unencodable information.
+func Var(info *buildinfo.BuildInfo) (expvar.Var, error) {
+	value, err := info.WithRuntime().JSON()
+	if err != nil {
+		return nil, err
+	}
+
+	raw := json.RawMessage(value)
+	result := expvar.Func(func() interface{} {
+		return raw
+	})
+
+	return result, nil
+}
+
+// Publish exports the given build information under the provided name
+// (see Var). Instead of panicking like expvar.Publish, ErrDuplicate is
+// returned if a variable with the same name has already been published.
+func Publish(name string, info *buildinfo.BuildInfo) error {
+	v, err := Var(info)
+	if err != nil {
+		return err
+	}
+
+	publishMu.Lock()
+	defer publishMu.Unlock()
+
+	if expvar.Get(name) != nil {
+		return fmt.Errorf("%w: %q", ErrDuplicate, name)
+	}
+
+	expvar.Publish(name, v)
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package expvarinfo
+
+import (
+	"encoding/json"
+	"errors"
+	"expvar"
+	"net/http/httptest"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/UiP9AV6Y/buildinfo"
+)
+
+func TestVar(t *testing.T) {
+	info := buildinfo.New()
+	info.Extras = map[string]string{"channel": "stable"}
+
+	v, err := Var(info)
+	assert.NilError(t, err)
+
+	want, err := info.WithRuntime().JSON()
+	assert.NilError(t, err)
+	assert.Equal(t, v.String(), string(want))
+
+	got, err := buildinfo.Parse([]byte(v.String()))
+	assert.NilError(t, err)
+	assert.Assert(t, got.VersionInfo.Equal(info.VersionInfo))
+	assert.Assert(t, got.Runtime != nil)
+	assert.Equal(t, got.Runtime.GoVersion, buildinfo.GoVersion)
+	assert.DeepEqual(t, got.Extras, info.Extras)
+
+	info.Version = "2.0.0"
+	assert.Equal(t, v.String(), string(want))
+}
+
+func TestPublish(t *testing.T) {
+	infoA := buildinfo.New()
+	infoA.Version = "1.0.0"
+	infoB := buildinfo.New()
+	infoB.Version = "2.0.0"
+
+	assert.NilError(t, Publish("test_component_a", infoA))
+	assert.NilError(t, Publish("test_component_b", infoB))
+
+	err := Publish("test_component_a", infoB)
+	assert.Assert(t, errors.Is(err, ErrDuplicate))
+
+	rec := httptest.NewRecorder()
+	expvar.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/debug/vars", nil))
+
+	var vars map[string]json.RawMessage
+	assert.NilError(t, json.Unmarshal(rec.Body.Bytes(), &vars))
+
+	for name, want := range map[string]string{"test_component_a": "1.0.0", "test_component_b": "2.0.0"} {
+		got, err := buildinfo.Parse(vars[name])
+		assert.NilError(t, err)
+		assert.Equal(t, got.Version, want, name)
+	}
+}
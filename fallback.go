@@ -0,0 +1,89 @@
+package buildinfo
+
+import (
+	"runtime/debug"
+	"time"
+)
+
+const (
+	// main module version reported by the toolchain for local builds
+	develVersion = "(devel)"
+	// debug.BuildSetting key for the VCS revision
+	vcsRevision = "vcs.revision"
+	// debug.BuildSetting key for the VCS commit date
+	vcsTime = "vcs.time"
+	// debug.BuildSetting key for the VCS worktree state
+	vcsModified = "vcs.modified"
+)
+
+// readBuildInfo is debug.ReadBuildInfo, replaceable for testing purposes
+var readBuildInfo = debug.ReadBuildInfo
+
+// NewFromRuntime returns a BuildInfo instance with default values,
+// which are overwritten by the information embedded into the binary
+// by the Golang toolchain where available.
+func NewFromRuntime() *BuildInfo {
+	result := New()
+	result.Date = time.Time{}
+
+	fallbackRuntime(result)
+
+	return result
+}
+
+func fallbackRuntime(i *BuildInfo) {
+	if bi, ok := readBuildInfo(); ok {
+		applyRuntime(i, bi)
+	}
+
+	if i.EnvironmentInfo != nil && i.Date.IsZero() {
+		i.Date = time.Now()
+	}
+}
+
+// applyRuntime copies the information from the given toolchain
+// build information into any field left at its default value.
+func applyRuntime(i *BuildInfo, bi *debug.BuildInfo) {
+	if i.VersionInfo == nil {
+		i.VersionInfo = NewVersionInfo()
+	}
+
+	if i.EnvironmentInfo == nil {
+		i.EnvironmentInfo = NewEnvironmentInfo()
+		i.Date = time.Time{}
+	}
+
+	if i.Version == "" || i.Version == DefaultVersion {
+		if v := bi.Main.Version; v != "" && v != develVersion {
+			i.Version = TrimVersionPrefix(v)
+		}
+	}
+
+	settings := make(map[string]string, len(bi.Settings))
+	for _, s := range bi.Settings {
+		settings[s.Key] = s.Value
+	}
+
+	revision := settings[vcsRevision]
+	if (i.Revision == "" || i.Revision == DefaultRevision) && revision != "" {
+		i.Revision = revision
+	}
+
+	date, err := time.Parse(time.RFC3339, settings[vcsTime])
+	if err == nil && i.Date.IsZero() {
+		i.Date = date
+	}
+
+	if revision == "" || i.Revision != revision {
+		// the remaining settings describe a different revision
+		return
+	}
+
+	if settings[vcsModified] == "true" {
+		i.Dirty = true
+	}
+
+	if err == nil && i.CommitDate == nil {
+		i.CommitDate = &date
+	}
+}
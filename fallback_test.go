@@ -0,0 +1,163 @@
+package buildinfo
+
+import (
+	"runtime/debug"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func mockReadBuildInfo(t *testing.T, bi *debug.BuildInfo) {
+	orig := readBuildInfo
+	readBuildInfo = func() (*debug.BuildInfo, bool) {
+		return bi, bi != nil
+	}
+
+	t.Cleanup(func() {
+		readBuildInfo = orig
+	})
+}
+
+func TestParseModeRuntimeFallback(t *testing.T) {
+	type testCase struct {
+		have        []byte
+		haveRuntime *debug.BuildInfo
+		wantError   bool
+		want        *VersionInfo
+		wantDate    time.Time
+	}
+
+	runtime := &debug.BuildInfo{
+		Main: debug.Module{
+			Path:    "example.com/test",
+			Version: "v1.2.3",
+		},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs", Value: "git"},
+			{Key: "vcs.revision", Value: "deadbeefcafe"},
+			{Key: "vcs.time", Value: "2023-11-14T22:13:20Z"},
+			{Key: "vcs.modified", Value: "true"},
+		},
+	}
+	commitDate := time.Unix(1700000000, 0)
+
+	testCases := map[string]testCase{
+		"empty": {
+			have:        []byte("{}"),
+			haveRuntime: runtime,
+			want: &VersionInfo{
+				Version:    "1.2.3",
+				Revision:   "deadbeefcafe",
+				Branch:     DefaultBranch,
+				Dirty:      true,
+				CommitDate: &commitDate,
+			},
+			wantDate: time.Unix(1700000000, 0),
+		},
+		"nil": {
+			haveRuntime: runtime,
+			want: &VersionInfo{
+				Version:    "1.2.3",
+				Revision:   "deadbeefcafe",
+				Branch:     DefaultBranch,
+				Dirty:      true,
+				CommitDate: &commitDate,
+			},
+			wantDate: time.Unix(1700000000, 0),
+		},
+		"explicit default": {
+			have:        []byte(`{"version":"0.0.0","revision":"HEAD"}`),
+			haveRuntime: runtime,
+			want: &VersionInfo{
+				Version:    "1.2.3",
+				Revision:   "deadbeefcafe",
+				Branch:     DefaultBranch,
+				Dirty:      true,
+				CommitDate: &commitDate,
+			},
+			wantDate: time.Unix(1700000000, 0),
+		},
+		"embedded precedence": {
+			have: []byte(`{
+				"version":"2.0.0",
+				"revision":"cafebabe",
+				"branch":"main",
+				"date":"1970-01-01T00:00:00Z"
+			}`),
+			haveRuntime: runtime,
+			want: &VersionInfo{
+				Version:  "2.0.0",
+				Revision: "cafebabe",
+				Branch:   "main",
+			},
+			wantDate: time.Unix(0, 0),
+		},
+		"devel": {
+			have: []byte("{}"),
+			haveRuntime: &debug.BuildInfo{
+				Main: debug.Module{
+					Path:    "example.com/test",
+					Version: "(devel)",
+				},
+			},
+			want: NewVersionInfo(),
+		},
+		"unavailable": {
+			have: []byte(`{"version":"2.0.0"}`),
+			want: &VersionInfo{
+				Version:  "2.0.0",
+				Revision: DefaultRevision,
+				Branch:   DefaultBranch,
+			},
+		},
+		"malformed": {
+			have:        []byte("{"),
+			haveRuntime: runtime,
+			wantError:   true,
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			mockReadBuildInfo(t, tc.haveRuntime)
+
+			got, err := ParseMode(tc.have, RuntimeFallback)
+
+			if tc.wantError {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+				assert.Assert(t, tc.want.Equal(got.VersionInfo), "want=%s; got=%s", tc.want, got)
+				assert.Assert(t, !got.Date.IsZero())
+				if !tc.wantDate.IsZero() {
+					assert.Assert(t, tc.wantDate.Equal(got.Date), "want=%s; got=%s", tc.wantDate, got.Date)
+				}
+			}
+		})
+	}
+}
+
+func TestNewFromRuntime(t *testing.T) {
+	mockReadBuildInfo(t, &debug.BuildInfo{
+		Main: debug.Module{
+			Path:    "example.com/test",
+			Version: "v0.1.0-rc.1",
+		},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "deadbeefcafe"},
+		},
+	})
+
+	got := NewFromRuntime()
+	want := &VersionInfo{
+		Version:  "0.1.0-rc.1",
+		Revision: "deadbeefcafe",
+		Branch:   DefaultBranch,
+	}
+
+	assert.Assert(t, want.Equal(got.VersionInfo), "want=%s; got=%s", want, got)
+	assert.Equal(t, got.User, DefaultUser)
+	assert.Equal(t, got.Host, DefaultHost)
+	assert.Assert(t, !got.Date.IsZero())
+}
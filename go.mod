@@ -2,7 +2,6 @@ module github.com/UiP9AV6Y/buildinfo
 
 go 1.19
 
-require (
-	github.com/google/go-cmp v0.5.9 // indirect
-	gotest.tools/v3 v3.5.1 // indirect
-)
+require gotest.tools/v3 v3.5.1
+
+require github.com/google/go-cmp v0.5.9 // indirect
@@ -0,0 +1,550 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: buildinfo.proto
+
+package buildinfopb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// GetBuildInfoRequest is the request of BuildInfoService.GetBuildInfo
+type GetBuildInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBuildInfoRequest) Reset() {
+	*x = GetBuildInfoRequest{}
+	mi := &file_buildinfo_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBuildInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBuildInfoRequest) ProtoMessage() {}
+
+func (x *GetBuildInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_buildinfo_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBuildInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetBuildInfoRequest) Descriptor() ([]byte, []int) {
+	return file_buildinfo_proto_rawDescGZIP(), []int{0}
+}
+
+// GetBuildInfoResponse is the response of BuildInfoService.GetBuildInfo
+type GetBuildInfoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BuildInfo     *BuildInfo             `protobuf:"bytes,1,opt,name=build_info,json=buildInfo,proto3" json:"build_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBuildInfoResponse) Reset() {
+	*x = GetBuildInfoResponse{}
+	mi := &file_buildinfo_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBuildInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBuildInfoResponse) ProtoMessage() {}
+
+func (x *GetBuildInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_buildinfo_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBuildInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetBuildInfoResponse) Descriptor() ([]byte, []int) {
+	return file_buildinfo_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetBuildInfoResponse) GetBuildInfo() *BuildInfo {
+	if x != nil {
+		return x.BuildInfo
+	}
+	return nil
+}
+
+// BuildInfo mirrors the buildinfo.BuildInfo type
+type BuildInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// version information
+	Version         string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Revision        string                 `protobuf:"bytes,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	Branch          string                 `protobuf:"bytes,3,opt,name=branch,proto3" json:"branch,omitempty"`
+	Dirty           bool                   `protobuf:"varint,4,opt,name=dirty,proto3" json:"dirty,omitempty"`
+	CommitDate      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=commit_date,json=commitDate,proto3" json:"commit_date,omitempty"`
+	CommitAuthor    string                 `protobuf:"bytes,6,opt,name=commit_author,json=commitAuthor,proto3" json:"commit_author,omitempty"`
+	CommitsSinceTag uint64                 `protobuf:"varint,7,opt,name=commits_since_tag,json=commitsSinceTag,proto3" json:"commits_since_tag,omitempty"`
+	// environment information
+	User          string                 `protobuf:"bytes,8,opt,name=user,proto3" json:"user,omitempty"`
+	Host          string                 `protobuf:"bytes,9,opt,name=host,proto3" json:"host,omitempty"`
+	Date          *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=date,proto3" json:"date,omitempty"`
+	Extras        map[string]string      `protobuf:"bytes,11,rep,name=extras,proto3" json:"extras,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Runtime       *RuntimeInfo           `protobuf:"bytes,12,opt,name=runtime,proto3" json:"runtime,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BuildInfo) Reset() {
+	*x = BuildInfo{}
+	mi := &file_buildinfo_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildInfo) ProtoMessage() {}
+
+func (x *BuildInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_buildinfo_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildInfo.ProtoReflect.Descriptor instead.
+func (*BuildInfo) Descriptor() ([]byte, []int) {
+	return file_buildinfo_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BuildInfo) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *BuildInfo) GetRevision() string {
+	if x != nil {
+		return x.Revision
+	}
+	return ""
+}
+
+func (x *BuildInfo) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+func (x *BuildInfo) GetDirty() bool {
+	if x != nil {
+		return x.Dirty
+	}
+	return false
+}
+
+func (x *BuildInfo) GetCommitDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CommitDate
+	}
+	return nil
+}
+
+func (x *BuildInfo) GetCommitAuthor() string {
+	if x != nil {
+		return x.CommitAuthor
+	}
+	return ""
+}
+
+func (x *BuildInfo) GetCommitsSinceTag() uint64 {
+	if x != nil {
+		return x.CommitsSinceTag
+	}
+	return 0
+}
+
+func (x *BuildInfo) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *BuildInfo) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *BuildInfo) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *BuildInfo) GetExtras() map[string]string {
+	if x != nil {
+		return x.Extras
+	}
+	return nil
+}
+
+func (x *BuildInfo) GetRuntime() *RuntimeInfo {
+	if x != nil {
+		return x.Runtime
+	}
+	return nil
+}
+
+// RuntimeInfo mirrors the buildinfo.RuntimeInfo type
+type RuntimeInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GoVersion     string                 `protobuf:"bytes,1,opt,name=go_version,json=goVersion,proto3" json:"go_version,omitempty"`
+	GoOs          string                 `protobuf:"bytes,2,opt,name=go_os,json=goOs,proto3" json:"go_os,omitempty"`
+	GoArch        string                 `protobuf:"bytes,3,opt,name=go_arch,json=goArch,proto3" json:"go_arch,omitempty"`
+	GoArchLevel   string                 `protobuf:"bytes,4,opt,name=go_arch_level,json=goArchLevel,proto3" json:"go_arch_level,omitempty"`
+	Compiler      string                 `protobuf:"bytes,5,opt,name=compiler,proto3" json:"compiler,omitempty"`
+	BuildTags     []string               `protobuf:"bytes,6,rep,name=build_tags,json=buildTags,proto3" json:"build_tags,omitempty"`
+	CgoEnabled    bool                   `protobuf:"varint,7,opt,name=cgo_enabled,json=cgoEnabled,proto3" json:"cgo_enabled,omitempty"`
+	TrimPath      bool                   `protobuf:"varint,8,opt,name=trim_path,json=trimPath,proto3" json:"trim_path,omitempty"`
+	Path          string                 `protobuf:"bytes,9,opt,name=path,proto3" json:"path,omitempty"`
+	Main          *Dependency            `protobuf:"bytes,10,opt,name=main,proto3" json:"main,omitempty"`
+	Deps          []*Dependency          `protobuf:"bytes,11,rep,name=deps,proto3" json:"deps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RuntimeInfo) Reset() {
+	*x = RuntimeInfo{}
+	mi := &file_buildinfo_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuntimeInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuntimeInfo) ProtoMessage() {}
+
+func (x *RuntimeInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_buildinfo_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuntimeInfo.ProtoReflect.Descriptor instead.
+func (*RuntimeInfo) Descriptor() ([]byte, []int) {
+	return file_buildinfo_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RuntimeInfo) GetGoVersion() string {
+	if x != nil {
+		return x.GoVersion
+	}
+	return ""
+}
+
+func (x *RuntimeInfo) GetGoOs() string {
+	if x != nil {
+		return x.GoOs
+	}
+	return ""
+}
+
+func (x *RuntimeInfo) GetGoArch() string {
+	if x != nil {
+		return x.GoArch
+	}
+	return ""
+}
+
+func (x *RuntimeInfo) GetGoArchLevel() string {
+	if x != nil {
+		return x.GoArchLevel
+	}
+	return ""
+}
+
+func (x *RuntimeInfo) GetCompiler() string {
+	if x != nil {
+		return x.Compiler
+	}
+	return ""
+}
+
+func (x *RuntimeInfo) GetBuildTags() []string {
+	if x != nil {
+		return x.BuildTags
+	}
+	return nil
+}
+
+func (x *RuntimeInfo) GetCgoEnabled() bool {
+	if x != nil {
+		return x.CgoEnabled
+	}
+	return false
+}
+
+func (x *RuntimeInfo) GetTrimPath() bool {
+	if x != nil {
+		return x.TrimPath
+	}
+	return false
+}
+
+func (x *RuntimeInfo) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *RuntimeInfo) GetMain() *Dependency {
+	if x != nil {
+		return x.Main
+	}
+	return nil
+}
+
+func (x *RuntimeInfo) GetDeps() []*Dependency {
+	if x != nil {
+		return x.Deps
+	}
+	return nil
+}
+
+// Dependency mirrors the buildinfo.Dependency type
+type Dependency struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Sum           string                 `protobuf:"bytes,3,opt,name=sum,proto3" json:"sum,omitempty"`
+	Replace       *Dependency            `protobuf:"bytes,4,opt,name=replace,proto3" json:"replace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Dependency) Reset() {
+	*x = Dependency{}
+	mi := &file_buildinfo_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Dependency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dependency) ProtoMessage() {}
+
+func (x *Dependency) ProtoReflect() protoreflect.Message {
+	mi := &file_buildinfo_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dependency.ProtoReflect.Descriptor instead.
+func (*Dependency) Descriptor() ([]byte, []int) {
+	return file_buildinfo_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Dependency) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Dependency) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Dependency) GetSum() string {
+	if x != nil {
+		return x.Sum
+	}
+	return ""
+}
+
+func (x *Dependency) GetReplace() *Dependency {
+	if x != nil {
+		return x.Replace
+	}
+	return nil
+}
+
+var File_buildinfo_proto protoreflect.FileDescriptor
+
+const file_buildinfo_proto_rawDesc = "" +
+	"\n" +
+	"\x0fbuildinfo.proto\x12\fbuildinfo.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x15\n" +
+	"\x13GetBuildInfoRequest\"N\n" +
+	"\x14GetBuildInfoResponse\x126\n" +
+	"\n" +
+	"build_info\x18\x01 \x01(\v2\x17.buildinfo.v1.BuildInfoR\tbuildInfo\"\x82\x04\n" +
+	"\tBuildInfo\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x1a\n" +
+	"\brevision\x18\x02 \x01(\tR\brevision\x12\x16\n" +
+	"\x06branch\x18\x03 \x01(\tR\x06branch\x12\x14\n" +
+	"\x05dirty\x18\x04 \x01(\bR\x05dirty\x12;\n" +
+	"\vcommit_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"commitDate\x12#\n" +
+	"\rcommit_author\x18\x06 \x01(\tR\fcommitAuthor\x12*\n" +
+	"\x11commits_since_tag\x18\a \x01(\x04R\x0fcommitsSinceTag\x12\x12\n" +
+	"\x04user\x18\b \x01(\tR\x04user\x12\x12\n" +
+	"\x04host\x18\t \x01(\tR\x04host\x12.\n" +
+	"\x04date\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12;\n" +
+	"\x06extras\x18\v \x03(\v2#.buildinfo.v1.BuildInfo.ExtrasEntryR\x06extras\x123\n" +
+	"\aruntime\x18\f \x01(\v2\x19.buildinfo.v1.RuntimeInfoR\aruntime\x1a9\n" +
+	"\vExtrasEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xe7\x02\n" +
+	"\vRuntimeInfo\x12\x1d\n" +
+	"\n" +
+	"go_version\x18\x01 \x01(\tR\tgoVersion\x12\x13\n" +
+	"\x05go_os\x18\x02 \x01(\tR\x04goOs\x12\x17\n" +
+	"\ago_arch\x18\x03 \x01(\tR\x06goArch\x12\"\n" +
+	"\rgo_arch_level\x18\x04 \x01(\tR\vgoArchLevel\x12\x1a\n" +
+	"\bcompiler\x18\x05 \x01(\tR\bcompiler\x12\x1d\n" +
+	"\n" +
+	"build_tags\x18\x06 \x03(\tR\tbuildTags\x12\x1f\n" +
+	"\vcgo_enabled\x18\a \x01(\bR\n" +
+	"cgoEnabled\x12\x1b\n" +
+	"\ttrim_path\x18\b \x01(\bR\btrimPath\x12\x12\n" +
+	"\x04path\x18\t \x01(\tR\x04path\x12,\n" +
+	"\x04main\x18\n" +
+	" \x01(\v2\x18.buildinfo.v1.DependencyR\x04main\x12,\n" +
+	"\x04deps\x18\v \x03(\v2\x18.buildinfo.v1.DependencyR\x04deps\"\x80\x01\n" +
+	"\n" +
+	"Dependency\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x10\n" +
+	"\x03sum\x18\x03 \x01(\tR\x03sum\x122\n" +
+	"\areplace\x18\x04 \x01(\v2\x18.buildinfo.v1.DependencyR\areplace2i\n" +
+	"\x10BuildInfoService\x12U\n" +
+	"\fGetBuildInfo\x12!.buildinfo.v1.GetBuildInfoRequest\x1a\".buildinfo.v1.GetBuildInfoResponseB0Z.github.com/UiP9AV6Y/buildinfo/grpc/buildinfopbb\x06proto3"
+
+var (
+	file_buildinfo_proto_rawDescOnce sync.Once
+	file_buildinfo_proto_rawDescData []byte
+)
+
+func file_buildinfo_proto_rawDescGZIP() []byte {
+	file_buildinfo_proto_rawDescOnce.Do(func() {
+		file_buildinfo_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_buildinfo_proto_rawDesc), len(file_buildinfo_proto_rawDesc)))
+	})
+	return file_buildinfo_proto_rawDescData
+}
+
+var file_buildinfo_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_buildinfo_proto_goTypes = []any{
+	(*GetBuildInfoRequest)(nil),   // 0: buildinfo.v1.GetBuildInfoRequest
+	(*GetBuildInfoResponse)(nil),  // 1: buildinfo.v1.GetBuildInfoResponse
+	(*BuildInfo)(nil),             // 2: buildinfo.v1.BuildInfo
+	(*RuntimeInfo)(nil),           // 3: buildinfo.v1.RuntimeInfo
+	(*Dependency)(nil),            // 4: buildinfo.v1.Dependency
+	nil,                           // 5: buildinfo.v1.BuildInfo.ExtrasEntry
+	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
+}
+var file_buildinfo_proto_depIdxs = []int32{
+	2, // 0: buildinfo.v1.GetBuildInfoResponse.build_info:type_name -> buildinfo.v1.BuildInfo
+	6, // 1: buildinfo.v1.BuildInfo.commit_date:type_name -> google.protobuf.Timestamp
+	6, // 2: buildinfo.v1.BuildInfo.date:type_name -> google.protobuf.Timestamp
+	5, // 3: buildinfo.v1.BuildInfo.extras:type_name -> buildinfo.v1.BuildInfo.ExtrasEntry
+	3, // 4: buildinfo.v1.BuildInfo.runtime:type_name -> buildinfo.v1.RuntimeInfo
+	4, // 5: buildinfo.v1.RuntimeInfo.main:type_name -> buildinfo.v1.Dependency
+	4, // 6: buildinfo.v1.RuntimeInfo.deps:type_name -> buildinfo.v1.Dependency
+	4, // 7: buildinfo.v1.Dependency.replace:type_name -> buildinfo.v1.Dependency
+	0, // 8: buildinfo.v1.BuildInfoService.GetBuildInfo:input_type -> buildinfo.v1.GetBuildInfoRequest
+	1, // 9: buildinfo.v1.BuildInfoService.GetBuildInfo:output_type -> buildinfo.v1.GetBuildInfoResponse
+	9, // [9:10] is the sub-list for method output_type
+	8, // [8:9] is the sub-list for method input_type
+	8, // [8:8] is the sub-list for extension type_name
+	8, // [8:8] is the sub-list for extension extendee
+	0, // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_buildinfo_proto_init() }
+func file_buildinfo_proto_init() {
+	if File_buildinfo_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_buildinfo_proto_rawDesc), len(file_buildinfo_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_buildinfo_proto_goTypes,
+		DependencyIndexes: file_buildinfo_proto_depIdxs,
+		MessageInfos:      file_buildinfo_proto_msgTypes,
+	}.Build()
+	File_buildinfo_proto = out.File
+	file_buildinfo_proto_goTypes = nil
+	file_buildinfo_proto_depIdxs = nil
+}
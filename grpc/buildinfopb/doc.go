@@ -0,0 +1,5 @@
+// Package buildinfopb contains the protobuf messages and gRPC service
+// definitions generated from proto/buildinfo.proto.
+package buildinfopb
+
+//go:generate sh -c "cd .. && buf generate proto"
@@ -0,0 +1,20 @@
+module github.com/UiP9AV6Y/buildinfo/grpc
+
+go 1.21
+
+require (
+	github.com/UiP9AV6Y/buildinfo v0.0.0-20240316121816-2a0a49f5d3c2
+	google.golang.org/grpc v1.65.0
+	google.golang.org/protobuf v1.34.2
+	gotest.tools/v3 v3.5.1
+)
+
+require (
+	github.com/google/go-cmp v0.6.0 // indirect
+	golang.org/x/net v0.25.0 // indirect
+	golang.org/x/sys v0.20.0 // indirect
+	golang.org/x/text v0.15.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240528184218-531527333157 // indirect
+)
+
+replace github.com/UiP9AV6Y/buildinfo => ../
@@ -0,0 +1,152 @@
+// Package grpcinfo provides gRPC integrations for build information.
+package grpcinfo
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/UiP9AV6Y/buildinfo"
+	"github.com/UiP9AV6Y/buildinfo/grpc/buildinfopb"
+)
+
+// ToProto converts the given build information into its protobuf
+// representation. Missing timestamps are omitted.
+func ToProto(info *buildinfo.BuildInfo) *buildinfopb.BuildInfo {
+	result := &buildinfopb.BuildInfo{}
+	if info == nil {
+		return result
+	}
+
+	if v := info.VersionInfo; v != nil {
+		result.Version = v.Version
+		result.Revision = v.Revision
+		result.Branch = v.Branch
+		result.Dirty = v.Dirty
+		result.CommitDate = toTimestamp(v.CommitDate)
+		result.CommitAuthor = v.CommitAuthor
+		result.CommitsSinceTag = uint64(v.CommitsSinceTag)
+	}
+
+	if e := info.EnvironmentInfo; e != nil {
+		result.User = e.User
+		result.Host = e.Host
+		result.Date = toTimestamp(&e.Date)
+	}
+
+	if len(info.Extras) > 0 {
+		result.Extras = make(map[string]string, len(info.Extras))
+		for k, v := range info.Extras {
+			result.Extras[k] = v
+		}
+	}
+
+	if r := info.Runtime; r != nil {
+		result.Runtime = &buildinfopb.RuntimeInfo{
+			GoVersion:   r.GoVersion,
+			GoOs:        r.GoOS,
+			GoArch:      r.GoArch,
+			GoArchLevel: r.ArchLevel,
+			Compiler:    r.Compiler,
+			BuildTags:   append([]string(nil), r.BuildTags...),
+			CgoEnabled:  r.CGOEnabled,
+			TrimPath:    r.TrimPath,
+			Path:        r.Path,
+			Main:        toDependency(r.Main),
+		}
+
+		for _, d := range r.Deps {
+			result.Runtime.Deps = append(result.Runtime.Deps, toDependency(d))
+		}
+	}
+
+	return result
+}
+
+// FromProto converts the given protobuf representation into
+// build information. Missing timestamps result in zero values.
+func FromProto(msg *buildinfopb.BuildInfo) *buildinfo.BuildInfo {
+	v := &buildinfo.VersionInfo{
+		Version:         msg.GetVersion(),
+		Revision:        msg.GetRevision(),
+		Branch:          msg.GetBranch(),
+		Dirty:           msg.GetDirty(),
+		CommitAuthor:    msg.GetCommitAuthor(),
+		CommitsSinceTag: uint(msg.GetCommitsSinceTag()),
+	}
+	if msg.GetCommitDate() != nil {
+		t := msg.GetCommitDate().AsTime()
+		v.CommitDate = &t
+	}
+
+	e := &buildinfo.EnvironmentInfo{
+		User: msg.GetUser(),
+		Host: msg.GetHost(),
+	}
+	if msg.GetDate() != nil {
+		e.Date = msg.GetDate().AsTime()
+	}
+
+	result := buildinfo.NewBuildInfo(v, e)
+	if len(msg.GetExtras()) > 0 {
+		result.Extras = make(map[string]string, len(msg.GetExtras()))
+		for k, v := range msg.GetExtras() {
+			result.Extras[k] = v
+		}
+	}
+
+	if r := msg.GetRuntime(); r != nil {
+		result.Runtime = &buildinfo.RuntimeInfo{
+			GoVersion:  r.GetGoVersion(),
+			GoOS:       r.GetGoOs(),
+			GoArch:     r.GetGoArch(),
+			ArchLevel:  r.GetGoArchLevel(),
+			Compiler:   r.GetCompiler(),
+			BuildTags:  append([]string(nil), r.GetBuildTags()...),
+			CGOEnabled: r.GetCgoEnabled(),
+			TrimPath:   r.GetTrimPath(),
+			Path:       r.GetPath(),
+			Main:       fromDependency(r.GetMain()),
+		}
+
+		for _, d := range r.GetDeps() {
+			result.Runtime.Deps = append(result.Runtime.Deps, fromDependency(d))
+		}
+	}
+
+	return result
+}
+
+func toTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(*t)
+}
+
+func toDependency(d *buildinfo.Dependency) *buildinfopb.Dependency {
+	if d == nil {
+		return nil
+	}
+
+	return &buildinfopb.Dependency{
+		Path:    d.Path,
+		Version: d.Version,
+		Sum:     d.Sum,
+		Replace: toDependency(d.Replace),
+	}
+}
+
+func fromDependency(d *buildinfopb.Dependency) *buildinfo.Dependency {
+	if d == nil {
+		return nil
+	}
+
+	return &buildinfo.Dependency{
+		Path:    d.GetPath(),
+		Version: d.GetVersion(),
+		Sum:     d.GetSum(),
+		Replace: fromDependency(d.GetReplace()),
+	}
+}
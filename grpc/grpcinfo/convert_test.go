@@ -0,0 +1,74 @@
+package grpcinfo
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/UiP9AV6Y/buildinfo"
+)
+
+func TestProtoRoundTrip(t *testing.T) {
+	// protobuf timestamps carry neither a location
+	// nor a monotonic clock reading
+	newInfo := func() *buildinfo.BuildInfo {
+		result := buildinfo.New()
+		result.Date = result.Date.UTC().Round(0)
+		return result
+	}
+
+	commitDate := time.Unix(1700000000, 123456789).UTC()
+	withVCS := newInfo()
+	withVCS.Dirty = true
+	withVCS.CommitDate = &commitDate
+	withVCS.CommitAuthor = "Gordon Bleux"
+	withVCS.CommitsSinceTag = 3
+	withVCS.Extras = map[string]string{
+		"channel": "stable",
+	}
+
+	withRuntime := newInfo()
+	withRuntime.Runtime = &buildinfo.RuntimeInfo{
+		GoVersion:  "go1.21.5",
+		GoOS:       "linux",
+		GoArch:     "arm",
+		ArchLevel:  "7",
+		Compiler:   "gc",
+		BuildTags:  []string{"netgo"},
+		CGOEnabled: true,
+		TrimPath:   true,
+		Path:       "example.com/app",
+		Main:       &buildinfo.Dependency{Path: "example.com/app", Version: "(devel)"},
+		Deps: []*buildinfo.Dependency{
+			{
+				Path:    "example.com/lib",
+				Version: "v1.0.0",
+				Sum:     "h1:abc=",
+				Replace: &buildinfo.Dependency{Path: "../lib"},
+			},
+		},
+	}
+
+	testCases := map[string]*buildinfo.BuildInfo{
+		"empty":    buildinfo.NewBuildInfo(&buildinfo.VersionInfo{}, &buildinfo.EnvironmentInfo{}),
+		"defaults": newInfo(),
+		"vcs":      withVCS,
+		"runtime":  withRuntime,
+	}
+
+	for ctx, have := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			got := FromProto(ToProto(have))
+			assert.Assert(t, got.Equal(have), "got %s, want %s", got, have)
+			assert.DeepEqual(t, got.Extras, have.Extras)
+			assert.Assert(t, got.Runtime.Equal(have.Runtime))
+		})
+	}
+}
+
+func TestToProtoNil(t *testing.T) {
+	got := ToProto(nil)
+	assert.Equal(t, got.GetVersion(), "")
+	assert.Assert(t, got.GetDate() == nil)
+}
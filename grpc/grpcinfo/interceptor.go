@@ -0,0 +1,75 @@
+package grpcinfo
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/UiP9AV6Y/buildinfo"
+)
+
+// Keys of the header metadata set by the server interceptors
+const (
+	// VersionKey contains the version of the server
+	VersionKey = "x-build-version"
+	// RevisionKey contains the VCS revision of the server
+	RevisionKey = "x-build-revision"
+	// DateKey contains the build date of the server (RFC 3339)
+	DateKey = "x-build-date"
+)
+
+// Metadata returns the header metadata derived from the given build
+// information. Keys with empty values are omitted.
+func Metadata(info *buildinfo.BuildInfo) metadata.MD {
+	md := metadata.MD{}
+	if info == nil {
+		return md
+	}
+
+	if v := info.VersionInfo; v != nil {
+		if v.Version != "" {
+			md.Set(VersionKey, v.Version)
+		}
+		if v.Revision != "" {
+			md.Set(RevisionKey, v.Revision)
+		}
+	}
+
+	if e := info.EnvironmentInfo; e != nil && !e.Date.IsZero() {
+		md.Set(DateKey, e.Date.Format(time.RFC3339))
+	}
+
+	return md
+}
+
+// UnaryServerInterceptor returns a server interceptor which attaches
+// the metadata of the given build information to the response header
+// of each unary call.
+func UnaryServerInterceptor(info *buildinfo.BuildInfo) grpc.UnaryServerInterceptor {
+	md := Metadata(info)
+
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := grpc.SetHeader(ctx, md); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a server interceptor which attaches
+// the metadata of the given build information to the response header
+// of each streaming call.
+func StreamServerInterceptor(info *buildinfo.BuildInfo) grpc.StreamServerInterceptor {
+	md := Metadata(info)
+
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := ss.SetHeader(md); err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,39 @@
+package grpcinfo
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/UiP9AV6Y/buildinfo"
+	"github.com/UiP9AV6Y/buildinfo/grpc/buildinfopb"
+)
+
+// Service implements the buildinfopb.BuildInfoServiceServer interface
+type Service struct {
+	buildinfopb.UnimplementedBuildInfoServiceServer
+
+	info *buildinfopb.BuildInfo
+}
+
+// NewService returns a Service instance serving the given build information.
+func NewService(info *buildinfo.BuildInfo) *Service {
+	return &Service{
+		info: ToProto(info),
+	}
+}
+
+// Register registers a Service instance serving the given
+// build information with the provided gRPC server.
+func Register(s grpc.ServiceRegistrar, info *buildinfo.BuildInfo) {
+	buildinfopb.RegisterBuildInfoServiceServer(s, NewService(info))
+}
+
+// GetBuildInfo implements the buildinfopb.BuildInfoServiceServer interface
+func (s *Service) GetBuildInfo(context.Context, *buildinfopb.GetBuildInfoRequest) (*buildinfopb.GetBuildInfoResponse, error) {
+	result := &buildinfopb.GetBuildInfoResponse{
+		BuildInfo: s.info,
+	}
+
+	return result, nil
+}
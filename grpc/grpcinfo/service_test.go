@@ -0,0 +1,121 @@
+package grpcinfo
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+	"gotest.tools/v3/assert"
+
+	"github.com/UiP9AV6Y/buildinfo"
+	"github.com/UiP9AV6Y/buildinfo/grpc/buildinfopb"
+)
+
+func newTestInfo() *buildinfo.BuildInfo {
+	result := buildinfo.New()
+	result.Version = "2.1.0"
+	result.Revision = "2a0a49f"
+	result.Date = time.Date(2024, 3, 16, 12, 18, 16, 0, time.UTC)
+
+	return result
+}
+
+func newTestConn(t *testing.T, info *buildinfo.BuildInfo) *grpc.ClientConn {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer(
+		grpc.UnaryInterceptor(UnaryServerInterceptor(info)),
+		grpc.StreamInterceptor(StreamServerInterceptor(info)),
+	)
+	Register(srv, info)
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NilError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func TestService(t *testing.T) {
+	info := newTestInfo()
+	conn := newTestConn(t, info)
+	client := buildinfopb.NewBuildInfoServiceClient(conn)
+
+	var header metadata.MD
+	got, err := client.GetBuildInfo(context.Background(), &buildinfopb.GetBuildInfoRequest{}, grpc.Header(&header))
+	assert.NilError(t, err)
+
+	assert.Assert(t, FromProto(got.GetBuildInfo()).Equal(info))
+	assert.DeepEqual(t, header.Get(VersionKey), []string{"2.1.0"})
+	assert.DeepEqual(t, header.Get(RevisionKey), []string{"2a0a49f"})
+	assert.DeepEqual(t, header.Get(DateKey), []string{"2024-03-16T12:18:16Z"})
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	conn := newTestConn(t, newTestInfo())
+	client := healthpb.NewHealthClient(conn)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	assert.NilError(t, err)
+
+	header, err := stream.Header()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, header.Get(VersionKey), []string{"2.1.0"})
+	assert.DeepEqual(t, header.Get(RevisionKey), []string{"2a0a49f"})
+}
+
+func TestMetadata(t *testing.T) {
+	type testCase struct {
+		have *buildinfo.BuildInfo
+		want metadata.MD
+	}
+
+	testCases := map[string]testCase{
+		"nil": {
+			have: nil,
+			want: metadata.MD{},
+		},
+		"empty": {
+			have: &buildinfo.BuildInfo{},
+			want: metadata.MD{},
+		},
+		"version": {
+			have: buildinfo.NewBuildInfo(&buildinfo.VersionInfo{Version: "1.0.0"}, nil),
+			want: metadata.Pairs(VersionKey, "1.0.0"),
+		},
+		"full": {
+			have: newTestInfo(),
+			want: metadata.Pairs(
+				VersionKey, "2.1.0",
+				RevisionKey, "2a0a49f",
+				DateKey, "2024-03-16T12:18:16Z",
+			),
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			assert.DeepEqual(t, Metadata(tc.have), tc.want)
+		})
+	}
+}
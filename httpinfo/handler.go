@@ -0,0 +1,171 @@
+// Package httpinfo provides net/http integrations for build information.
+package httpinfo
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"html/template"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/UiP9AV6Y/buildinfo"
+)
+
+const (
+	contentTypeJSON = "application/json"
+	contentTypeText = "text/plain"
+	contentTypeHTML = "text/html"
+	charset         = "; charset=utf-8"
+)
+
+// offers contains the supported media types in order of preference
+var offers = []string{
+	contentTypeJSON,
+	contentTypeText,
+	contentTypeHTML,
+}
+
+// htmlTmpl contains the page rendered for HTML requests
+var htmlTmpl = template.Must(template.New("html").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Program}} {{.Version}}</title>
+</head>
+<body>
+<h1>{{.Program}}</h1>
+<table>
+<tr><th>version</th><td>{{.Version}}</td></tr>
+<tr><th>revision</th><td>{{.Revision}}</td></tr>
+<tr><th>branch</th><td>{{.Branch}}</td></tr>
+{{- if .Dirty}}
+<tr><th>dirty</th><td>{{.Dirty}}</td></tr>
+{{- end}}
+{{- with .User}}
+<tr><th>build user</th><td>{{.}}</td></tr>
+{{- end}}
+{{- with .Host}}
+<tr><th>build host</th><td>{{.}}</td></tr>
+{{- end}}
+<tr><th>build date</th><td>{{.Date}}</td></tr>
+<tr><th>go version</th><td>{{.Runtime.GoVersion}}</td></tr>
+<tr><th>platform</th><td>{{.Runtime.Platform}}</td></tr>
+{{- range $k := .ExtrasKeys}}
+<tr><th>{{$k}}</th><td>{{index $.Extras $k}}</td></tr>
+{{- end}}
+</table>
+</body>
+</html>
+`))
+
+// Opts contains the settings of the Handler
+type Opts struct {
+	// Program is the name used in the plain text and HTML output
+	Program string
+	// RedactEnvironment removes the name of the user
+	// and host the binary has been built by/on.
+	RedactEnvironment bool
+}
+
+// representation is a pre-rendered response body
+type representation struct {
+	contentType string
+	etag        string
+	body        []byte
+}
+
+// Handler serves build information in the representation requested
+// via the Accept header. Only GET and HEAD requests are supported.
+type Handler struct {
+	modTime         time.Time
+	representations []*representation
+}
+
+// New returns a Handler serving the given build information.
+// An error is returned if the information can not be rendered.
+func New(info *buildinfo.BuildInfo, opts Opts) (*Handler, error) {
+	info = info.Clone()
+	if info.VersionInfo == nil {
+		info.VersionInfo = buildinfo.NewVersionInfo()
+	}
+	if info.EnvironmentInfo == nil {
+		info.EnvironmentInfo = buildinfo.NewEnvironmentInfo()
+	}
+
+	if opts.RedactEnvironment {
+		info.User = ""
+		info.Host = ""
+	}
+
+	jsonBody, err := info.JSON()
+	if err != nil {
+		return nil, err
+	}
+
+	textBody := []byte(info.Print(opts.Program) + "\n")
+
+	var htmlBody bytes.Buffer
+	if err := htmlTmpl.Execute(&htmlBody, buildinfo.NewPrintData(info, opts.Program)); err != nil {
+		return nil, err
+	}
+
+	tag := entityTag(info)
+	result := &Handler{
+		modTime: info.Date,
+		representations: []*representation{
+			{contentType: contentTypeJSON, etag: fmt.Sprintf(`"%s-json"`, tag), body: jsonBody},
+			{contentType: contentTypeText + charset, etag: fmt.Sprintf(`"%s-text"`, tag), body: textBody},
+			{contentType: contentTypeHTML + charset, etag: fmt.Sprintf(`"%s-html"`, tag), body: htmlBody.Bytes()},
+		},
+	}
+
+	return result, nil
+}
+
+// ServeHTTP implements the http.Handler interface
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r) {
+		return
+	}
+
+	w.Header().Add("Vary", "Accept")
+
+	n := negotiate(r.Header.Get("Accept"), offers)
+	if n < 0 {
+		http.Error(w, http.StatusText(http.StatusNotAcceptable), http.StatusNotAcceptable)
+		return
+	}
+
+	rep := h.representations[n]
+	w.Header().Set("Content-Type", rep.contentType)
+	w.Header().Set("ETag", rep.etag)
+
+	http.ServeContent(w, r, "", h.modTime, bytes.NewReader(rep.body))
+}
+
+// allowMethod reports whether the request uses a supported method.
+// Otherwise a 405 response is sent.
+func allowMethod(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return true
+	}
+
+	w.Header().Set("Allow", strings.Join([]string{http.MethodGet, http.MethodHead}, ", "))
+	http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+	return false
+}
+
+// entityTag derives a validator from the version, revision and build date
+func entityTag(info *buildinfo.BuildInfo) string {
+	h := fnv.New64a()
+	h.Write([]byte(info.Version))
+	h.Write([]byte{0})
+	h.Write([]byte(info.Revision))
+	h.Write([]byte{0})
+	h.Write([]byte(info.Date.UTC().Format(time.RFC3339Nano)))
+
+	return fmt.Sprintf("%016x", h.Sum64())
+}
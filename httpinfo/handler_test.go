@@ -0,0 +1,188 @@
+package httpinfo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/UiP9AV6Y/buildinfo"
+)
+
+func newTestInfo() *buildinfo.BuildInfo {
+	return &buildinfo.BuildInfo{
+		VersionInfo: &buildinfo.VersionInfo{
+			Version:  "1.2.3",
+			Revision: "deadbeef",
+			Branch:   "main",
+		},
+		EnvironmentInfo: &buildinfo.EnvironmentInfo{
+			User: "root",
+			Host: "example.com",
+			Date: time.Unix(1700000000, 0).UTC(),
+		},
+		Extras: map[string]string{
+			"channel": "<stable>",
+		},
+	}
+}
+
+func TestHandler(t *testing.T) {
+	type testCase struct {
+		haveMethod  string
+		haveHeaders map[string]string
+		wantStatus  int
+		wantType    string
+		wantBody    []string
+	}
+
+	tag := entityTag(newTestInfo())
+	testCases := map[string]testCase{
+		"no accept": {
+			haveMethod: http.MethodGet,
+			wantStatus: http.StatusOK,
+			wantType:   "application/json",
+			wantBody:   []string{`"version":"1.2.3"`, `"user":"root"`},
+		},
+		"any": {
+			haveMethod:  http.MethodGet,
+			haveHeaders: map[string]string{"Accept": "*/*"},
+			wantStatus:  http.StatusOK,
+			wantType:    "application/json",
+		},
+		"text": {
+			haveMethod:  http.MethodGet,
+			haveHeaders: map[string]string{"Accept": "text/plain"},
+			wantStatus:  http.StatusOK,
+			wantType:    "text/plain; charset=utf-8",
+			wantBody:    []string{"test, version 1.2.3 (branch: main, revision: deadbeef)", "channel:"},
+		},
+		"browser": {
+			haveMethod:  http.MethodGet,
+			haveHeaders: map[string]string{"Accept": "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8"},
+			wantStatus:  http.StatusOK,
+			wantType:    "text/html; charset=utf-8",
+			wantBody:    []string{"<title>test 1.2.3</title>", "&lt;stable&gt;"},
+		},
+		"quality": {
+			haveMethod:  http.MethodGet,
+			haveHeaders: map[string]string{"Accept": "application/json;q=0.5, text/*;q=0.7, text/html;q=0.1"},
+			wantStatus:  http.StatusOK,
+			wantType:    "text/plain; charset=utf-8",
+		},
+		"not acceptable": {
+			haveMethod:  http.MethodGet,
+			haveHeaders: map[string]string{"Accept": "application/xml, text/plain;q=0"},
+			wantStatus:  http.StatusNotAcceptable,
+		},
+		"head": {
+			haveMethod: http.MethodHead,
+			wantStatus: http.StatusOK,
+			wantType:   "application/json",
+		},
+		"not modified etag": {
+			haveMethod:  http.MethodGet,
+			haveHeaders: map[string]string{"If-None-Match": `"` + tag + `-json"`},
+			wantStatus:  http.StatusNotModified,
+		},
+		"modified etag": {
+			haveMethod:  http.MethodGet,
+			haveHeaders: map[string]string{"If-None-Match": `"` + tag + `-text"`},
+			wantStatus:  http.StatusOK,
+			wantType:    "application/json",
+		},
+		"not modified since": {
+			haveMethod:  http.MethodGet,
+			haveHeaders: map[string]string{"If-Modified-Since": "Tue, 14 Nov 2023 22:13:20 GMT"},
+			wantStatus:  http.StatusNotModified,
+		},
+		"post": {
+			haveMethod: http.MethodPost,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	h, err := New(newTestInfo(), Opts{Program: "test"})
+	assert.NilError(t, err)
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			req := httptest.NewRequest(tc.haveMethod, "/version", nil)
+			for k, v := range tc.haveHeaders {
+				req.Header.Set(k, v)
+			}
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			res := rec.Result()
+
+			assert.Equal(t, res.StatusCode, tc.wantStatus)
+
+			switch tc.wantStatus {
+			case http.StatusOK:
+				assert.Equal(t, res.Header.Get("Content-Type"), tc.wantType)
+				assert.Equal(t, res.Header.Get("Vary"), "Accept")
+				assert.Equal(t, res.Header.Get("Last-Modified"), "Tue, 14 Nov 2023 22:13:20 GMT")
+				assert.Assert(t, strings.HasPrefix(res.Header.Get("ETag"), `"`+tag+"-"))
+			case http.StatusMethodNotAllowed:
+				assert.Equal(t, res.Header.Get("Allow"), "GET, HEAD")
+			}
+
+			body := rec.Body.String()
+			if tc.haveMethod == http.MethodHead {
+				assert.Equal(t, body, "")
+			}
+
+			for _, want := range tc.wantBody {
+				assert.Assert(t, strings.Contains(body, want), "want=%q; got=%q", want, body)
+			}
+		})
+	}
+}
+
+func TestHandlerRedactEnvironment(t *testing.T) {
+	info := newTestInfo()
+	h, err := New(info, Opts{Program: "test", RedactEnvironment: true})
+	assert.NilError(t, err)
+
+	for _, accept := range offers {
+		req := httptest.NewRequest(http.MethodGet, "/version", nil)
+		req.Header.Set("Accept", accept)
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		body := rec.Body.String()
+		assert.Equal(t, rec.Code, http.StatusOK)
+		assert.Assert(t, !strings.Contains(body, "root"), "body=%q", body)
+		assert.Assert(t, !strings.Contains(body, "example.com"), "body=%q", body)
+	}
+
+	assert.Equal(t, info.User, "root")
+}
+
+func TestNegotiate(t *testing.T) {
+	type testCase struct {
+		have string
+		want int
+	}
+
+	testCases := map[string]testCase{
+		"empty":       {have: "", want: 0},
+		"exact":       {have: "text/html", want: 2},
+		"wildcard":    {have: "text/*", want: 1},
+		"specificity": {have: "text/*;q=0.2, text/html;q=0.9", want: 2},
+		"excluded":    {have: "*/*, application/json;q=0", want: 1},
+		"malformed":   {have: "json, text/plain;q=abc", want: 1},
+		"none":        {have: "image/png", want: -1},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			assert.Equal(t, negotiate(tc.have, offers), tc.want)
+		})
+	}
+}
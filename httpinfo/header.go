@@ -0,0 +1,128 @@
+package httpinfo
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"text/template"
+
+	"github.com/UiP9AV6Y/buildinfo"
+)
+
+const (
+	// DefaultVersionHeader is the response header set by Middleware
+	DefaultVersionHeader = "X-Build-Version"
+	// DefaultUserAgentHeader is the request header set by Transport
+	DefaultUserAgentHeader = "User-Agent"
+)
+
+var (
+	// DefaultVersionTemplate renders the value of DefaultVersionHeader
+	DefaultVersionTemplate = template.Must(buildinfo.NewPrintTemplate("version",
+		`{{.VersionRevision}}`))
+	// DefaultUserAgentTemplate renders the value of DefaultUserAgentHeader
+	DefaultUserAgentTemplate = template.Must(buildinfo.NewPrintTemplate("useragent",
+		`{{.Program}}/{{.Version}} ({{.Revision}}; {{.Runtime.Platform}})`))
+)
+
+// ErrInvalidHeader is the error used when rendering an invalid header value
+var ErrInvalidHeader = errors.New("invalid header value")
+
+// HeaderOpts contains the settings of Middleware and Transport
+type HeaderOpts struct {
+	// Program is the name available to the template
+	Program string
+	// Name is the header to set. If empty,
+	// the default of the respective consumer is used.
+	Name string
+	// Template renders the header value using buildinfo.PrintData
+	// (see buildinfo.NewPrintTemplate). If nil, the default of the
+	// respective consumer is used.
+	Template *template.Template
+}
+
+// header renders the header name and value
+func (o HeaderOpts) header(info *buildinfo.BuildInfo, name string, tmpl *template.Template) (string, string, error) {
+	if o.Name != "" {
+		name = o.Name
+	}
+
+	if o.Template != nil {
+		tmpl = o.Template
+	}
+
+	value, err := info.PrintTemplate(o.Program, tmpl)
+	if err != nil {
+		return "", "", err
+	}
+
+	if strings.ContainsAny(value, "\r\n") {
+		return "", "", ErrInvalidHeader
+	}
+
+	return http.CanonicalHeaderKey(name), value, nil
+}
+
+// Middleware returns a function wrapping http.Handler instances, which
+// adds a header derived from the given build information to each response.
+// An error is returned if the header value can not be rendered.
+func Middleware(info *buildinfo.BuildInfo, opts HeaderOpts) (func(http.Handler) http.Handler, error) {
+	name, value, err := opts.header(info, DefaultVersionHeader, DefaultVersionTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	middleware := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(name, value)
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	return middleware, nil
+}
+
+// Transport is an http.RoundTripper adding a header derived from build
+// information to each request, unless the request already contains it.
+type Transport struct {
+	// Base is the RoundTripper used to make the requests.
+	// If nil, http.DefaultTransport is used.
+	Base http.RoundTripper
+
+	name, value string
+}
+
+// NewTransport returns a Transport instance using the given RoundTripper.
+// An error is returned if the header value can not be rendered.
+func NewTransport(base http.RoundTripper, info *buildinfo.BuildInfo, opts HeaderOpts) (*Transport, error) {
+	name, value, err := opts.header(info, DefaultUserAgentHeader, DefaultUserAgentTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Transport{
+		Base:  base,
+		name:  name,
+		value: value,
+	}
+
+	return result, nil
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+// The given request is not modified.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if req.Header.Get(t.name) != "" {
+		return base.RoundTrip(req)
+	}
+
+	req2 := req.Clone(req.Context())
+	req2.Header.Set(t.name, t.value)
+
+	return base.RoundTrip(req2)
+}
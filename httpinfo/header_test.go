@@ -0,0 +1,159 @@
+package httpinfo
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"text/template"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/UiP9AV6Y/buildinfo"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestMiddleware(t *testing.T) {
+	type testCase struct {
+		haveOpts  HeaderOpts
+		wantName  string
+		wantValue string
+	}
+
+	testCases := map[string]testCase{
+		"default": {
+			wantName:  "X-Build-Version",
+			wantValue: "1.2.3-deadbeef",
+		},
+		"custom": {
+			haveOpts: HeaderOpts{
+				Program:  "test",
+				Name:     "x-app-version",
+				Template: template.Must(buildinfo.NewPrintTemplate("custom", "{{.Program}}@{{.Version}}")),
+			},
+			wantName:  "X-App-Version",
+			wantValue: "test@1.2.3",
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			middleware, err := Middleware(newTestInfo(), tc.haveOpts)
+			assert.NilError(t, err)
+
+			h := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusTeapot)
+			}))
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			assert.Equal(t, rec.Code, http.StatusTeapot)
+			assert.Equal(t, rec.Header().Get(tc.wantName), tc.wantValue)
+		})
+	}
+}
+
+func TestMiddlewareInvalid(t *testing.T) {
+	_, err := Middleware(newTestInfo(), HeaderOpts{
+		Template: template.Must(buildinfo.NewPrintTemplate("broken", "{{.Version}}\r\nX-Injected: 1")),
+	})
+	assert.Assert(t, errors.Is(err, ErrInvalidHeader))
+
+	_, err = Middleware(newTestInfo(), HeaderOpts{
+		Template: template.Must(buildinfo.NewPrintTemplate("broken", "{{.Unknown}}")),
+	})
+	assert.Assert(t, err != nil)
+}
+
+func TestTransport(t *testing.T) {
+	type testCase struct {
+		haveOpts    HeaderOpts
+		haveHeaders map[string]string
+		wantName    string
+		wantValue   string
+	}
+
+	mockGoRuntime(t)
+
+	testCases := map[string]testCase{
+		"default": {
+			haveOpts:  HeaderOpts{Program: "test"},
+			wantName:  "User-Agent",
+			wantValue: "test/1.2.3 (deadbeef; linux/amd64)",
+		},
+		"explicit": {
+			haveOpts:    HeaderOpts{Program: "test"},
+			haveHeaders: map[string]string{"User-Agent": "curl/8.0"},
+			wantName:    "User-Agent",
+			wantValue:   "curl/8.0",
+		},
+		"custom": {
+			haveOpts: HeaderOpts{
+				Name:     "X-Client-Version",
+				Template: template.Must(buildinfo.NewPrintTemplate("custom", "{{.Version}}")),
+			},
+			wantName:  "X-Client-Version",
+			wantValue: "1.2.3",
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			var got *http.Request
+			base := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				got = r
+				return &http.Response{StatusCode: http.StatusNoContent, Request: r}, nil
+			})
+
+			transport, err := NewTransport(base, newTestInfo(), tc.haveOpts)
+			assert.NilError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+			for k, v := range tc.haveHeaders {
+				req.Header.Set(k, v)
+			}
+
+			res, err := transport.RoundTrip(req)
+			assert.NilError(t, err)
+			assert.Equal(t, res.StatusCode, http.StatusNoContent)
+			assert.Equal(t, got.Header.Get(tc.wantName), tc.wantValue)
+			assert.Equal(t, req.Header.Get(tc.wantName), tc.haveHeaders[tc.wantName])
+		})
+	}
+}
+
+func TestTransportClient(t *testing.T) {
+	mockGoRuntime(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Echo", r.UserAgent())
+	}))
+	defer srv.Close()
+
+	transport, err := NewTransport(nil, newTestInfo(), HeaderOpts{Program: "test"})
+	assert.NilError(t, err)
+
+	transport.Base = srv.Client().Transport
+	client := &http.Client{Transport: transport}
+
+	res, err := client.Get(srv.URL)
+	assert.NilError(t, err)
+	res.Body.Close()
+
+	assert.Equal(t, res.Header.Get("X-Echo"), "test/1.2.3 (deadbeef; linux/amd64)")
+}
+
+func mockGoRuntime(t *testing.T) {
+	goVersion, goOS, goArch := buildinfo.GoVersion, buildinfo.GoOS, buildinfo.GoArch
+	buildinfo.GoVersion, buildinfo.GoOS, buildinfo.GoArch = "go1.19.13", "linux", "amd64"
+
+	t.Cleanup(func() {
+		buildinfo.GoVersion, buildinfo.GoOS, buildinfo.GoArch = goVersion, goOS, goArch
+	})
+}
@@ -0,0 +1,90 @@
+package httpinfo
+
+import (
+	"strconv"
+	"strings"
+)
+
+// mediaRange is a single entry of an Accept header
+type mediaRange struct {
+	typ, subtype string
+	q            float64
+}
+
+// matches reports whether the given media type is covered by the range,
+// and how specific the match is (0 for */*, 1 for type/*, 2 for type/subtype).
+func (r *mediaRange) matches(typ, subtype string) (bool, int) {
+	switch {
+	case r.typ == "*" && r.subtype == "*":
+		return true, 0
+	case r.typ == typ && r.subtype == "*":
+		return true, 1
+	case r.typ == typ && r.subtype == subtype:
+		return true, 2
+	}
+
+	return false, -1
+}
+
+// parseAccept parses the given Accept header value.
+// Malformed entries are ignored.
+func parseAccept(accept string) []*mediaRange {
+	var result []*mediaRange
+
+	for _, entry := range strings.Split(accept, ",") {
+		params := strings.Split(entry, ";")
+		typ, subtype, ok := strings.Cut(strings.TrimSpace(params[0]), "/")
+		if !ok || typ == "" || subtype == "" {
+			continue
+		}
+
+		r := &mediaRange{
+			typ:     strings.ToLower(typ),
+			subtype: strings.ToLower(subtype),
+			q:       1,
+		}
+
+		for _, p := range params[1:] {
+			k, v, _ := strings.Cut(strings.TrimSpace(p), "=")
+			if !strings.EqualFold(k, "q") {
+				continue
+			}
+
+			if q, err := strconv.ParseFloat(v, 64); err == nil && q >= 0 && q <= 1 {
+				r.q = q
+			}
+		}
+
+		result = append(result, r)
+	}
+
+	return result
+}
+
+// negotiate returns the index of the offered media type preferred
+// by the given Accept header value, or -1 if none is acceptable.
+// An empty header accepts any type. Offers of equal quality are
+// resolved by their order.
+func negotiate(accept string, offers []string) int {
+	if strings.TrimSpace(accept) == "" {
+		return 0
+	}
+
+	ranges := parseAccept(accept)
+	best, bestQ := -1, 0.0
+	for n, offer := range offers {
+		typ, subtype, _ := strings.Cut(offer, "/")
+		q, specificity := 0.0, -1
+		for _, r := range ranges {
+			if ok, s := r.matches(typ, subtype); ok && s > specificity {
+				q, specificity = r.q, s
+			}
+		}
+
+		if q > bestQ {
+			best, bestQ = n, q
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,105 @@
+package httpinfo
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/UiP9AV6Y/buildinfo"
+)
+
+// registryOffers contains the media types supported
+// by the RegistryHandler in order of preference
+var registryOffers = []string{
+	contentTypeJSON,
+	contentTypeText,
+}
+
+// RegistryHandler serves the build information of all components of a
+// buildinfo.Registry in the representation requested via the Accept
+// header. The JSON representation is an object with the component names
+// as keys, the plain text one contains the Print output of each component.
+// Only GET and HEAD requests are supported.
+type RegistryHandler struct {
+	reg  *buildinfo.Registry
+	opts Opts
+}
+
+// NewRegistryHandler returns a RegistryHandler serving the components of
+// the given registry (buildinfo.DefaultRegistry if nil). The responses are
+// rendered for each request, so components registered later are included.
+// Opts.Program is not used, as the component names take its place.
+func NewRegistryHandler(reg *buildinfo.Registry, opts Opts) *RegistryHandler {
+	if reg == nil {
+		reg = buildinfo.DefaultRegistry
+	}
+
+	return &RegistryHandler{
+		reg:  reg,
+		opts: opts,
+	}
+}
+
+// ServeHTTP implements the http.Handler interface
+func (h *RegistryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r) {
+		return
+	}
+
+	w.Header().Add("Vary", "Accept")
+
+	n := negotiate(r.Header.Get("Accept"), registryOffers)
+	if n < 0 {
+		http.Error(w, http.StatusText(http.StatusNotAcceptable), http.StatusNotAcceptable)
+		return
+	}
+
+	infos := h.reg.All()
+	if h.opts.RedactEnvironment {
+		for _, info := range infos {
+			if info.EnvironmentInfo != nil {
+				info.User = ""
+				info.Host = ""
+			}
+		}
+	}
+
+	var body []byte
+	switch registryOffers[n] {
+	case contentTypeJSON:
+		b, err := json.Marshal(infos)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", contentTypeJSON)
+		body = b
+	default:
+		var buf strings.Builder
+		components := make([]string, 0, len(infos))
+		for k := range infos {
+			components = append(components, k)
+		}
+
+		sort.Strings(components)
+		for i, component := range components {
+			if i > 0 {
+				buf.WriteString("\n")
+			}
+
+			buf.WriteString(infos[component].Print(component))
+			buf.WriteString("\n")
+		}
+
+		w.Header().Set("Content-Type", contentTypeText+charset)
+		body = []byte(buf.String())
+	}
+
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	_, _ = w.Write(body)
+}
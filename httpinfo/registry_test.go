@@ -0,0 +1,96 @@
+package httpinfo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/UiP9AV6Y/buildinfo"
+)
+
+func TestRegistryHandler(t *testing.T) {
+	reg := buildinfo.NewRegistry()
+	reg.MustRegister("core", newTestInfo())
+
+	h := NewRegistryHandler(reg, Opts{RedactEnvironment: true})
+
+	plugin := newTestInfo()
+	plugin.Version = "0.1.0"
+	reg.MustRegister("plugin", plugin)
+
+	type testCase struct {
+		haveMethod string
+		haveAccept string
+		wantStatus int
+		wantType   string
+		wantBody   string
+	}
+
+	testCases := map[string]testCase{
+		"json": {
+			haveMethod: http.MethodGet,
+			wantStatus: http.StatusOK,
+			wantType:   "application/json",
+		},
+		"text": {
+			haveMethod: http.MethodGet,
+			haveAccept: "text/plain",
+			wantStatus: http.StatusOK,
+			wantType:   "text/plain; charset=utf-8",
+			wantBody:   "core, version 1.2.3 (branch: main, revision: deadbeef)",
+		},
+		"head": {
+			haveMethod: http.MethodHead,
+			wantStatus: http.StatusOK,
+			wantType:   "application/json",
+		},
+		"not acceptable": {
+			haveMethod: http.MethodGet,
+			haveAccept: "text/html",
+			wantStatus: http.StatusNotAcceptable,
+		},
+		"post": {
+			haveMethod: http.MethodPost,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			req := httptest.NewRequest(tc.haveMethod, "/components", nil)
+			if tc.haveAccept != "" {
+				req.Header.Set("Accept", tc.haveAccept)
+			}
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			assert.Equal(t, rec.Code, tc.wantStatus)
+			if tc.wantStatus != http.StatusOK {
+				return
+			}
+
+			body := rec.Body.String()
+			assert.Equal(t, rec.Header().Get("Content-Type"), tc.wantType)
+			assert.Assert(t, !strings.Contains(body, "root"), "body=%q", body)
+
+			switch {
+			case tc.haveMethod == http.MethodHead:
+				assert.Equal(t, body, "")
+			case tc.wantType == contentTypeJSON:
+				var got map[string]*buildinfo.BuildInfo
+				assert.NilError(t, json.Unmarshal([]byte(body), &got))
+				assert.Equal(t, len(got), 2)
+				assert.Equal(t, got["core"].Version, "1.2.3")
+				assert.Equal(t, got["plugin"].Version, "0.1.0")
+			default:
+				assert.Assert(t, strings.Contains(body, tc.wantBody), "body=%q", body)
+				assert.Assert(t, strings.Contains(body, "\n\nplugin, version 0.1.0"), "body=%q", body)
+			}
+		})
+	}
+}
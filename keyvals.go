@@ -0,0 +1,75 @@
+package buildinfo
+
+// Keyvals returns the version information as alternating keys and values,
+// suitable for structured loggers like go-kit (log.With). The keys are the
+// same as the ones of the text encoding; empty fields are omitted.
+func (i *VersionInfo) Keyvals() []interface{} {
+	var result []interface{}
+
+	result = appendKeyvalString(result, textVersion, i.Version)
+	result = appendKeyvalString(result, textRevision, i.Revision)
+	result = appendKeyvalString(result, textBranch, i.Branch)
+	if i.Dirty {
+		result = append(result, textDirty, i.Dirty)
+	}
+	if i.CommitDate != nil {
+		result = append(result, textCommitDate, *i.CommitDate)
+	}
+	result = appendKeyvalString(result, textCommitAuthor, i.CommitAuthor)
+	if i.CommitsSinceTag > 0 {
+		result = append(result, textCommitsSinceTag, i.CommitsSinceTag)
+	}
+
+	return result
+}
+
+// Keyvals returns the environment information as alternating keys and values,
+// suitable for structured loggers like go-kit (log.With). The keys are the
+// same as the ones of the text encoding; empty fields are omitted.
+func (i *EnvironmentInfo) Keyvals() []interface{} {
+	var result []interface{}
+
+	result = appendKeyvalString(result, textUser, i.User)
+	result = appendKeyvalString(result, textHost, i.Host)
+	if !i.Date.IsZero() {
+		result = append(result, textDate, i.Date)
+	}
+
+	return result
+}
+
+// Keyvals returns the version- and environment information followed by
+// the Extras as alternating keys and values, suitable for structured
+// loggers like go-kit:
+//
+//	logger = log.With(logger, info.Keyvals()...)
+//
+// The keys are the same as the ones of the text encoding;
+// empty fields are omitted. The Runtime information is not included.
+func (i *BuildInfo) Keyvals() []interface{} {
+	var result []interface{}
+
+	if i.VersionInfo != nil {
+		result = append(result, i.VersionInfo.Keyvals()...)
+	}
+
+	if i.EnvironmentInfo != nil {
+		result = append(result, i.EnvironmentInfo.Keyvals()...)
+	}
+
+	for _, k := range i.ExtrasKeys() {
+		result = append(result, textExtraPrefix+k, i.Extras[k])
+	}
+
+	return result
+}
+
+// appendKeyvalString appends the given key and
+// value unless the latter is empty
+func appendKeyvalString(kv []interface{}, key, value string) []interface{} {
+	if value == "" {
+		return kv
+	}
+
+	return append(kv, key, value)
+}
@@ -0,0 +1,73 @@
+package buildinfo
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestBuildInfoKeyvals(t *testing.T) {
+	commitDate := time.Unix(123450000, 0).UTC()
+	date := time.Unix(123456790, 0).UTC()
+
+	type testCase struct {
+		have *BuildInfo
+		want []interface{}
+	}
+
+	testCases := map[string]testCase{
+		"empty": {
+			have: &BuildInfo{},
+			want: nil,
+		},
+		"minimal": {
+			have: &BuildInfo{
+				VersionInfo: &VersionInfo{Version: "1.2.3"},
+			},
+			want: []interface{}{"version", "1.2.3"},
+		},
+		"full": {
+			have: &BuildInfo{
+				VersionInfo: &VersionInfo{
+					Version:         "1.2.3",
+					Revision:        "deadbeef",
+					Branch:          "main",
+					Dirty:           true,
+					CommitDate:      &commitDate,
+					CommitAuthor:    "Gordon Bleux",
+					CommitsSinceTag: 3,
+				},
+				EnvironmentInfo: &EnvironmentInfo{
+					User: "root",
+					Host: "example.com",
+					Date: date,
+				},
+				Extras: map[string]string{
+					"sku":     "enterprise",
+					"channel": "stable",
+				},
+			},
+			want: []interface{}{
+				"version", "1.2.3",
+				"revision", "deadbeef",
+				"branch", "main",
+				"dirty", true,
+				"commit_date", commitDate,
+				"commit_author", "Gordon Bleux",
+				"commits_since_tag", uint(3),
+				"user", "root",
+				"host", "example.com",
+				"date", date,
+				"extra.channel", "stable",
+				"extra.sku", "enterprise",
+			},
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			assert.DeepEqual(t, tc.have.Keyvals(), tc.want)
+		})
+	}
+}
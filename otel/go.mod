@@ -0,0 +1,23 @@
+module github.com/UiP9AV6Y/buildinfo/otel
+
+go 1.22
+
+require (
+	github.com/UiP9AV6Y/buildinfo v0.0.0-20240316121816-2a0a49f5d3c2
+	github.com/google/go-cmp v0.6.0
+	go.opentelemetry.io/otel v1.32.0
+	go.opentelemetry.io/otel/metric v1.32.0
+	go.opentelemetry.io/otel/sdk v1.32.0
+	go.opentelemetry.io/otel/sdk/metric v1.32.0
+	gotest.tools/v3 v3.5.1
+)
+
+require (
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	go.opentelemetry.io/otel/trace v1.32.0 // indirect
+	golang.org/x/sys v0.27.0 // indirect
+)
+
+replace github.com/UiP9AV6Y/buildinfo => ../
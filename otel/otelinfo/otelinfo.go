@@ -0,0 +1,122 @@
+// Package otelinfo provides OpenTelemetry integrations for build information.
+package otelinfo
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+
+	"github.com/UiP9AV6Y/buildinfo"
+)
+
+const (
+	// BuildInfoMetric is the name of the gauge registered by RegisterBuildInfo
+	BuildInfoMetric = "build_info"
+	// ExtraPrefix is the namespace of the extras in the resource attributes
+	ExtraPrefix = "buildinfo.extra."
+)
+
+// Attributes returns the resource attributes describing the given
+// build information using the semantic conventions. The program is
+// used as service name and omitted if empty. Extras are added as
+// additional attributes, using their key prefixed with ExtraPrefix.
+func Attributes(info *buildinfo.BuildInfo, program string) []attribute.KeyValue {
+	result := make([]attribute.KeyValue, 0, 8+len(info.Extras))
+	if program != "" {
+		result = append(result, semconv.ServiceName(program))
+	}
+
+	if v := info.VersionInfo; v != nil {
+		if v.Version != "" {
+			result = append(result, semconv.ServiceVersion(v.Version))
+		}
+		if v.Revision != "" {
+			result = append(result, semconv.VCSRepositoryRefRevision(v.Revision))
+		}
+		if v.Branch != "" {
+			result = append(result,
+				semconv.VCSRepositoryRefName(v.Branch),
+				semconv.VCSRepositoryRefTypeBranch)
+		}
+	}
+
+	goVersion := buildinfo.GoVersion
+	if r := info.Runtime; r != nil {
+		goVersion = r.GoVersion
+	}
+
+	result = append(result,
+		semconv.ProcessRuntimeName("go"),
+		semconv.ProcessRuntimeVersion(goVersion))
+
+	return appendExtras(result, info, ExtraPrefix)
+}
+
+// Resource returns a resource containing the Attributes of the
+// given build information. It is intended to be merged with
+// other resources, e.g. resource.Default. The resource carries
+// no schema URL, so merging does not depend on the semantic
+// conventions version of the SDK.
+func Resource(info *buildinfo.BuildInfo, program string) *resource.Resource {
+	return resource.NewSchemaless(Attributes(info, program)...)
+}
+
+// RegisterBuildInfo registers an observable gauge with the given meter,
+// which reports a constant '1' value with attributes equivalent to the
+// labels of the Prometheus build_info metric. The toolchain information
+// is taken from the runtime information if present.
+func RegisterBuildInfo(meter metric.Meter, info *buildinfo.BuildInfo, program string) (metric.Int64ObservableGauge, error) {
+	goVersion, goOS, goArch := buildinfo.GoVersion, buildinfo.GoOS, buildinfo.GoArch
+	if r := info.Runtime; r != nil {
+		goVersion, goOS, goArch = r.GoVersion, r.GoOS, r.GoArch
+	}
+
+	var version, revision, branch string
+	if v := info.VersionInfo; v != nil {
+		version, revision, branch = v.Version, v.Revision, v.Branch
+	}
+
+	attrs := []attribute.KeyValue{
+		attribute.String("version", version),
+		attribute.String("revision", revision),
+		attribute.String("branch", branch),
+		attribute.String("goversion", goVersion),
+		attribute.String("goos", goOS),
+		attribute.String("goarch", goArch),
+	}
+	attrs = appendExtras(attrs, info, "")
+	set := metric.WithAttributeSet(attribute.NewSet(attrs...))
+
+	return meter.Int64ObservableGauge(BuildInfoMetric,
+		metric.WithDescription("A metric with a constant '1' value labeled by version, revision, branch, "+
+			"goversion from which "+program+" was built, and the goos and goarch for the build."),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			o.Observe(1, set)
+			return nil
+		}),
+	)
+}
+
+// appendExtras adds the extras of the given information
+// in lexical order, skipping keys already present in attrs
+func appendExtras(attrs []attribute.KeyValue, info *buildinfo.BuildInfo, prefix string) []attribute.KeyValue {
+	seen := make(map[attribute.Key]struct{}, len(attrs))
+	for _, a := range attrs {
+		seen[a.Key] = struct{}{}
+	}
+
+	for _, k := range info.ExtrasKeys() {
+		name := attribute.Key(prefix + k)
+		if _, ok := seen[name]; ok {
+			continue
+		}
+
+		seen[name] = struct{}{}
+		attrs = append(attrs, attribute.String(string(name), info.Extras[k]))
+	}
+
+	return attrs
+}
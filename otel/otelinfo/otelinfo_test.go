@@ -0,0 +1,132 @@
+package otelinfo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"gotest.tools/v3/assert"
+
+	"github.com/UiP9AV6Y/buildinfo"
+)
+
+// newTestInfo returns default information with extras, one of
+// them named like a semantic convention attribute; the prefix
+// keeps it from overriding that attribute.
+func newTestInfo() *buildinfo.BuildInfo {
+	result := buildinfo.New().WithRuntime()
+	result.Extras = map[string]string{
+		"channel":         "stable",
+		"service.version": "9.9.9",
+	}
+
+	return result
+}
+
+func TestAttributes(t *testing.T) {
+	type testCase struct {
+		have    *buildinfo.BuildInfo
+		program string
+		want    []attribute.KeyValue
+	}
+
+	testCases := map[string]testCase{
+		"full": {
+			have:    newTestInfo(),
+			program: "test",
+			want: []attribute.KeyValue{
+				attribute.String("service.name", "test"),
+				attribute.String("service.version", buildinfo.DefaultVersion),
+				attribute.String("vcs.repository.ref.revision", buildinfo.DefaultRevision),
+				attribute.String("vcs.repository.ref.name", buildinfo.DefaultBranch),
+				attribute.String("vcs.repository.ref.type", "branch"),
+				attribute.String("process.runtime.name", "go"),
+				attribute.String("process.runtime.version", buildinfo.GoVersion),
+				attribute.String("buildinfo.extra.channel", "stable"),
+				attribute.String("buildinfo.extra.service.version", "9.9.9"),
+			},
+		},
+		"minimal": {
+			have: &buildinfo.BuildInfo{
+				VersionInfo: &buildinfo.VersionInfo{Version: "0.1.0"},
+				Runtime:     &buildinfo.RuntimeInfo{GoVersion: "go1.22.0"},
+			},
+			want: []attribute.KeyValue{
+				attribute.String("service.version", "0.1.0"),
+				attribute.String("process.runtime.name", "go"),
+				attribute.String("process.runtime.version", "go1.22.0"),
+			},
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			got := Attributes(tc.have, tc.program)
+			assert.DeepEqual(t, got, tc.want, cmp.Comparer(func(a, b attribute.Value) bool {
+				return a == b
+			}))
+
+			res := Resource(tc.have, tc.program)
+			want := attribute.NewSet(tc.want...)
+			assert.Assert(t, res.Set().Equals(&want), "got %v", res.Attributes())
+		})
+	}
+}
+
+func TestResourceMerge(t *testing.T) {
+	res, err := resource.Merge(resource.Default(), Resource(newTestInfo(), "test"))
+	assert.NilError(t, err)
+	assert.Equal(t, res.SchemaURL(), resource.Default().SchemaURL())
+
+	got, ok := res.Set().Value("service.version")
+	assert.Assert(t, ok)
+	assert.Equal(t, got.AsString(), buildinfo.DefaultVersion)
+
+	got, ok = res.Set().Value("buildinfo.extra.channel")
+	assert.Assert(t, ok)
+	assert.Equal(t, got.AsString(), "stable")
+
+	got, ok = res.Set().Value(ExtraPrefix + "service.version")
+	assert.Assert(t, ok)
+	assert.Equal(t, got.AsString(), "9.9.9")
+}
+
+func TestRegisterBuildInfo(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	defer provider.Shutdown(context.Background()) //nolint:errcheck
+
+	_, err := RegisterBuildInfo(provider.Meter("test"), newTestInfo(), "test")
+	assert.NilError(t, err)
+
+	var rm metricdata.ResourceMetrics
+	assert.NilError(t, reader.Collect(context.Background(), &rm))
+	assert.Equal(t, len(rm.ScopeMetrics), 1)
+	assert.Equal(t, len(rm.ScopeMetrics[0].Metrics), 1)
+
+	m := rm.ScopeMetrics[0].Metrics[0]
+	assert.Equal(t, m.Name, BuildInfoMetric)
+
+	gauge, ok := m.Data.(metricdata.Gauge[int64])
+	assert.Assert(t, ok, "unexpected data type %T", m.Data)
+	assert.Equal(t, len(gauge.DataPoints), 1)
+
+	dp := gauge.DataPoints[0]
+	assert.Equal(t, dp.Value, int64(1))
+
+	want := attribute.NewSet(
+		attribute.String("version", buildinfo.DefaultVersion),
+		attribute.String("revision", buildinfo.DefaultRevision),
+		attribute.String("branch", buildinfo.DefaultBranch),
+		attribute.String("goversion", buildinfo.GoVersion),
+		attribute.String("goos", buildinfo.GoOS),
+		attribute.String("goarch", buildinfo.GoArch),
+		attribute.String("channel", "stable"),
+		attribute.String("service.version", "9.9.9"),
+	)
+	assert.Assert(t, dp.Attributes.Equals(&want), "got %v", dp.Attributes.ToSlice())
+}
@@ -0,0 +1,233 @@
+package buildinfo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+	"time"
+)
+
+// Names of the built-in formats available to BuildInfo.PrintFormat
+const (
+	// FormatDefault is the multi-line layout used by BuildInfo.Print
+	FormatDefault = "default"
+	// FormatOneline is a single human-readable line
+	FormatOneline = "oneline"
+	// FormatTable is an aligned two-column key/value table
+	FormatTable = "table"
+	// FormatJSON is the JSON encoding including the runtime information
+	FormatJSON = "json"
+	// FormatLogfmt is the text encoding followed by the runtime information
+	FormatLogfmt = "logfmt"
+)
+
+// ErrUnknownFormat is the error used when requesting an unknown format
+var ErrUnknownFormat = errors.New("unknown format")
+
+// printFormat is a built-in template
+type printFormat struct {
+	tmpl *template.Template
+	// tabular indicates tab separated output,
+	// which is aligned after the rendering
+	tabular bool
+}
+
+// printFormats contains the built-in templates
+var printFormats = map[string]*printFormat{
+	FormatDefault: {
+		tmpl: mustPrintTemplate(FormatDefault, `{{.Program}}, version {{.Version}} (branch: {{.Branch}}, revision: {{.ShortRevision}})
+  build user:       {{.User}}
+  build host:       {{.Host}}
+  build date:       {{.Date}}
+  go version:       {{.Runtime.GoVersion}}
+  platform:         {{.Runtime.Platform}}
+{{- if .BuildSettings}}
+  build tags:       {{.Runtime.Tags}}
+  cgo enabled:      {{.Runtime.CGOEnabled}}
+  trimpath:         {{.Runtime.TrimPath}}
+  arch level:       {{.Runtime.ArchLevel}}
+{{- end}}
+{{- range $k := .ExtrasKeys}}
+  {{printf "%-18s" (print $k ":")}}{{index $.Extras $k}}
+{{- end}}`),
+	},
+	FormatOneline: {
+		tmpl: mustPrintTemplate(FormatOneline, `{{.Program}} {{.Version}} `+
+			`({{.ShortRevision}}{{if .Dirty}}-dirty{{end}}, {{.Branch}}) `+
+			`built by {{.UserHost}} on {{rfc3339 .Date}} `+
+			`with {{.Runtime.GoVersion}} for {{.Runtime.Platform}}`),
+	},
+	FormatTable: {
+		tmpl: mustPrintTemplate(FormatTable, `program	{{.Program}}
+version	{{.Version}}
+revision	{{.Revision}}
+branch	{{.Branch}}
+dirty	{{.Dirty}}
+{{- with .CommitDate}}
+commit date	{{rfc3339 .}}
+{{- end}}
+{{- with .CommitAuthor}}
+commit author	{{.}}
+{{- end}}
+{{- if .CommitsSinceTag}}
+commits since tag	{{.CommitsSinceTag}}
+{{- end}}
+build user	{{.User}}
+build host	{{.Host}}
+build date	{{rfc3339 .Date}}
+go version	{{.Runtime.GoVersion}}
+platform	{{.Runtime.Platform}}
+{{- if .BuildSettings}}
+build tags	{{.Runtime.Tags}}
+cgo enabled	{{.Runtime.CGOEnabled}}
+trimpath	{{.Runtime.TrimPath}}
+arch level	{{.Runtime.ArchLevel}}
+{{- end}}
+{{- range $k := .ExtrasKeys}}
+{{$k}}	{{index $.Extras $k}}
+{{- end}}`),
+		tabular: true,
+	},
+	FormatJSON: {
+		tmpl: mustPrintTemplate(FormatJSON, `{{json .BuildInfo}}`),
+	},
+	FormatLogfmt: {
+		tmpl: mustPrintTemplate(FormatLogfmt, `program={{logfmt .Program}} {{text .BuildInfo}}`+
+			` goversion={{logfmt .Runtime.GoVersion}} platform={{logfmt .Runtime.Platform}}`+
+			`{{if .BuildSettings}} tags={{logfmt .Runtime.Tags}} cgo={{.Runtime.CGOEnabled}}`+
+			` trimpath={{.Runtime.TrimPath}} goarchlevel={{logfmt .Runtime.ArchLevel}}{{end}}`),
+	},
+}
+
+// printFuncs contains the functions available to print templates
+var printFuncs = template.FuncMap{
+	// json returns the JSON encoding of the given value
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	// text returns the text encoding of the given BuildInfo
+	"text": func(i *BuildInfo) (string, error) {
+		b, err := i.MarshalText()
+		return string(b), err
+	},
+	// logfmt quotes the given value if necessary
+	"logfmt": func(v string) string {
+		return string(appendTextToken(nil, v))
+	},
+	// rfc3339 formats the given timestamp
+	"rfc3339": func(t time.Time) string {
+		return t.Format(time.RFC3339)
+	},
+}
+
+// PrintData is the data passed to the templates of BuildInfo.PrintTemplate.
+// Missing version- and environment information is substituted with default
+// values. Runtime is always available; if no runtime information has been
+// attached (see BuildInfo.WithRuntime), it only contains the toolchain
+// information of GoVersion, GoOS and GoArch.
+type PrintData struct {
+	*BuildInfo
+
+	// Program is the name provided by the caller
+	Program string
+	// BuildSettings reports whether Runtime contains the build
+	// settings in addition to the toolchain information
+	BuildSettings bool
+}
+
+// NewPrintTemplate parses the given text as template for BuildInfo.PrintTemplate.
+// In addition to the builtin template functions, the following are available:
+// json (JSON encoding of any value), text (text encoding of a BuildInfo),
+// logfmt (quotes a string if necessary) and rfc3339 (formats a time.Time).
+func NewPrintTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(printFuncs).Parse(text)
+}
+
+func mustPrintTemplate(name, text string) *template.Template {
+	return template.Must(NewPrintTemplate(name, text))
+}
+
+// PrintFormats returns the names of the built-in formats in lexical order
+func PrintFormats() []string {
+	result := make([]string, 0, len(printFormats))
+	for k := range printFormats {
+		result = append(result, k)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// PrintFormat renders the information using the built-in format with the
+// given name (see PrintFormats). The FormatDefault output is identical to
+// the one of Print.
+func (i *BuildInfo) PrintFormat(program, format string) (string, error) {
+	f, ok := printFormats[format]
+	if !ok {
+		return "", fmt.Errorf("%w %q; valid values include %s",
+			ErrUnknownFormat, format, strings.Join(PrintFormats(), ", "))
+	}
+
+	info, err := i.PrintTemplate(program, f.tmpl)
+	if err != nil || !f.tabular {
+		return info, err
+	}
+
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	if _, err := w.Write([]byte(info + "\n")); err != nil {
+		return "", err
+	}
+
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// PrintTemplate renders the information using the given template,
+// which is executed with a PrintData instance.
+func (i *BuildInfo) PrintTemplate(program string, tmpl *template.Template) (string, error) {
+	var buf strings.Builder
+
+	if err := tmpl.Execute(&buf, NewPrintData(i, program)); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// NewPrintData returns the template data for the given information.
+// The instance is copied before defaults are substituted.
+func NewPrintData(i *BuildInfo, program string) *PrintData {
+	info := i.Clone()
+	if info.VersionInfo == nil {
+		info.VersionInfo = NewVersionInfo()
+	}
+	if info.EnvironmentInfo == nil {
+		info.EnvironmentInfo = NewEnvironmentInfo()
+	}
+
+	settings := info.Runtime != nil
+	if !settings {
+		info.Runtime = &RuntimeInfo{
+			GoVersion: GoVersion,
+			GoOS:      GoOS,
+			GoArch:    GoArch,
+		}
+	}
+
+	return &PrintData{
+		BuildInfo:     info,
+		Program:       program,
+		BuildSettings: settings,
+	}
+}
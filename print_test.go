@@ -0,0 +1,132 @@
+package buildinfo
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/golden"
+)
+
+func TestBuildInfoPrintFormat(t *testing.T) {
+	mockGoRuntime(t)
+
+	commitDate := time.Unix(123450000, 0).UTC()
+	testCases := map[string]*BuildInfo{
+		"nil": {
+			VersionInfo: nil,
+			EnvironmentInfo: &EnvironmentInfo{
+				User: "root",
+				Host: "example.com",
+				Date: time.Unix(123456790, 0).UTC(),
+			},
+		},
+		"full": {
+			VersionInfo: &VersionInfo{
+				Version:         "1.2.3",
+				Revision:        "deadbeefcafe",
+				Branch:          "unstable",
+				Dirty:           true,
+				CommitDate:      &commitDate,
+				CommitAuthor:    "Gordon Bleux",
+				CommitsSinceTag: 3,
+			},
+			EnvironmentInfo: &EnvironmentInfo{
+				User: "root",
+				Host: "example.com",
+				Date: time.Unix(123456790, 0).UTC(),
+			},
+		},
+		"runtime": {
+			VersionInfo: &VersionInfo{
+				Version:  "1.2.3",
+				Revision: "deadbeef",
+				Branch:   "unstable",
+			},
+			EnvironmentInfo: &EnvironmentInfo{
+				User: "root",
+				Host: "example.com",
+				Date: time.Unix(123456790, 0).UTC(),
+			},
+			Runtime: &RuntimeInfo{
+				GoVersion:  "go1.21.5",
+				GoOS:       "linux",
+				GoArch:     "arm",
+				ArchLevel:  "7",
+				BuildTags:  []string{"netgo", "osusergo"},
+				CGOEnabled: false,
+				TrimPath:   true,
+			},
+		},
+		"extras": {
+			VersionInfo: &VersionInfo{
+				Version:  "1.2.3",
+				Revision: "deadbeef",
+				Branch:   "unstable",
+			},
+			EnvironmentInfo: &EnvironmentInfo{
+				User: "root",
+				Host: "example.com",
+				Date: time.Unix(123456790, 0).UTC(),
+			},
+			Extras: map[string]string{
+				"sku":      "enterprise",
+				"channel":  "stable",
+				"pipeline": "build 12345",
+			},
+		},
+	}
+
+	for _, format := range PrintFormats() {
+		for ctx, have := range testCases {
+			t.Run(format+"/"+ctx, func(t *testing.T) {
+				got, err := have.PrintFormat("test", format)
+				assert.NilError(t, err)
+
+				want := "print_" + format + "_" + ctx + ".golden"
+				if format == FormatDefault {
+					// shared with TestBuildInfoPrint
+					want = "print_" + ctx + ".golden"
+				}
+
+				golden.Assert(t, got, want)
+
+				if format == FormatDefault && have.VersionInfo != nil {
+					assert.Equal(t, got, have.Print("test"))
+				}
+			})
+		}
+	}
+}
+
+func TestBuildInfoPrintFormatUnknown(t *testing.T) {
+	_, err := New().PrintFormat("test", "yaml")
+	assert.Assert(t, errors.Is(err, ErrUnknownFormat))
+}
+
+func TestBuildInfoPrintTemplate(t *testing.T) {
+	mockGoRuntime(t)
+
+	tmpl, err := NewPrintTemplate("custom", `{{.Program}}/{{.Version}} {{logfmt .Branch}} {{.Runtime.GoVersion}} {{.BuildSettings}}`)
+	assert.NilError(t, err)
+
+	have := &BuildInfo{
+		VersionInfo: &VersionInfo{
+			Version: "1.2.3",
+			Branch:  "feature branch",
+		},
+	}
+
+	got, err := have.PrintTemplate("test", tmpl)
+	assert.NilError(t, err)
+	assert.Equal(t, got, `test/1.2.3 "feature branch" go1.19.13 false`)
+	assert.Assert(t, have.EnvironmentInfo == nil)
+	assert.Assert(t, have.Runtime == nil)
+
+	tmpl, err = NewPrintTemplate("broken", `{{.Unknown}}`)
+	assert.NilError(t, err)
+
+	_, err = have.PrintTemplate("test", tmpl)
+	assert.Assert(t, err != nil)
+}
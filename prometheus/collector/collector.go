@@ -1,32 +1,455 @@
 package collector
 
 import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/UiP9AV6Y/buildinfo"
 )
 
+const (
+	// DefaultName is the metric name used if Opts.Name is empty
+	DefaultName = "build_info"
+	// TimestampName is the name of the build timestamp metric
+	TimestampName = "build_timestamp_seconds"
+	// AgeName is the name of the build age metric (see Opts.BuildAge)
+	AgeName = "build_age_seconds"
+)
+
+// Builtin label sources available to Opts.Labels
+const (
+	LabelVersion   = "version"
+	LabelRevision  = "revision"
+	LabelBranch    = "branch"
+	LabelDirty     = "dirty"
+	LabelGoVersion = "goversion"
+	LabelGoOS      = "goos"
+	LabelGoArch    = "goarch"
+	LabelUser      = "user"
+	LabelHost      = "host"
+)
+
+var (
+	// ErrInvalidName is the error used when the resulting
+	// metric name does not satisfy the naming rules
+	ErrInvalidName = errors.New("invalid metric name")
+	// ErrInvalidLabel is the error used for label names
+	// which do not satisfy the naming rules
+	ErrInvalidLabel = errors.New("invalid label name")
+	// ErrUnknownLabel is the error used when selecting
+	// a label source which does not exist
+	ErrUnknownLabel = errors.New("unknown label")
+	// ErrDuplicateLabel is the error used when
+	// multiple labels share the same name
+	ErrDuplicateLabel = errors.New("duplicate label")
+)
+
+// Opts contains the settings of the build information collector
+type Opts struct {
+	// Program is the name used in the help text.
+	// If empty, the Namespace is used instead.
+	Program string
+	// Namespace is the first component of the metric name.
+	// Unsupported characters are replaced with underscores.
+	Namespace string
+	// Subsystem is the optional second component of the metric name
+	Subsystem string
+	// Name is the last component of the metric name.
+	// If empty, DefaultName is used.
+	Name string
+	// Labels selects the builtin labels (see the Label* constants)
+	// and their names. Keys are label sources, values the names of
+	// the exported labels; an empty value retains the source name.
+	// If nil, DefaultLabels is used. The help text of the metric
+	// lists the exported names of a non-default selection.
+	Labels map[string]string
+	// ConstLabels are added to the builtin labels as-is
+	ConstLabels prometheus.Labels
+	// OmitExtras excludes the buildinfo.BuildInfo.Extras labels
+	OmitExtras bool
+	// BuildTimestamp enables the build timestamp metric, which
+	// reports the build date as Unix timestamp. It is disabled by
+	// default, as build information lacking an embedded date
+	// carries the time it was parsed at instead.
+	BuildTimestamp bool
+	// BuildAge enables the build age metric, which reports the
+	// number of seconds elapsed since the build at collection time
+	BuildAge bool
+	// Info exposes the build information as OpenMetrics info metric
+	// when the collector is passed to HandlerFor and the scrape
+	// negotiates OpenMetrics.
+	// The metric name must end with "_info".
+	Info bool
+}
+
+// metricName returns the validated name of the build information
+// metric and the sanitized namespace
+func (o Opts) metricName() (fqName, namespace string, err error) {
+	name := o.Name
+	if name == "" {
+		name = DefaultName
+	}
+
+	namespace = o.Namespace
+	if namespace != "" {
+		namespace = labelName(namespace)
+	}
+
+	fqName = prometheus.BuildFQName(namespace, o.Subsystem, name)
+	if !validMetricName(fqName) {
+		return "", "", fmt.Errorf("%w: %q", ErrInvalidName, fqName)
+	}
+
+	if o.Info && !strings.HasSuffix(fqName, infoSuffix) {
+		return "", "", fmt.Errorf("%w: %q lacks the %q suffix required for info metrics",
+			ErrInvalidName, fqName, infoSuffix)
+	}
+
+	return fqName, namespace, nil
+}
+
+// program returns the name used in help texts
+func (o Opts) program() string {
+	if o.Program != "" {
+		return o.Program
+	}
+
+	return o.Namespace
+}
+
+// DefaultLabels returns the label selection used if Opts.Labels is nil
+func DefaultLabels() map[string]string {
+	return map[string]string{
+		LabelVersion:   LabelVersion,
+		LabelRevision:  LabelRevision,
+		LabelBranch:    LabelBranch,
+		LabelGoVersion: LabelGoVersion,
+		LabelGoOS:      LabelGoOS,
+		LabelGoArch:    LabelGoArch,
+	}
+}
+
+// buildInfoCollector exports the build information metric
+// and the build timestamp and -age metrics if enabled
+type buildInfoCollector struct {
+	desc          *prometheus.Desc
+	timestampDesc *prometheus.Desc
+	ageDesc       *prometheus.Desc
+	info          string
+	date          time.Time
+	now           func() time.Time
+}
+
 // New returns a collector that exports metrics
 // using the provided data as information source.
+// The toolchain information is taken from the runtime
+// information if present. Extras are added as additional
+// labels, with their keys sanitized to form valid label
+// names; entries clashing with the builtin labels are ignored,
+// while keys resulting in reserved names ("__" prefix) let the
+// registration of the collector fail.
+// The program is used as (sanitized) namespace; if the metric
+// name is still invalid, the registration of the collector fails.
 func New(buildInfo *buildinfo.BuildInfo, program string) prometheus.Collector {
-	help := "A metric with a constant '1' value labeled by version, revision, branch, goversion from which " +
-		program + " was built, and the goos and goarch for the build."
-	labels := prometheus.Labels{
-		"version":   buildInfo.Version,
-		"revision":  buildInfo.Revision,
-		"branch":    buildInfo.Branch,
-		"goversion": buildinfo.GoVersion,
-		"goos":      buildinfo.GoOS,
-		"goarch":    buildinfo.GoArch,
-	}
-
-	return prometheus.NewGaugeFunc(
-		prometheus.GaugeOpts{
-			Namespace:   program,
-			Name:        "build_info",
-			Help:        help,
-			ConstLabels: labels,
-		},
-		func() float64 { return 1 },
-	)
+	c, err := NewWithOpts(buildInfo, Opts{Program: program, Namespace: program})
+	if err != nil {
+		return &buildInfoCollector{desc: prometheus.NewInvalidDesc(err)}
+	}
+
+	return c
+}
+
+// NewWithOpts returns a collector that exports metrics using the
+// provided data as information source, configured by the given options.
+// An error is returned if the metric or a label name is invalid,
+// including extras whose sanitized key is a reserved label name.
+//
+// In addition to the build information, the build date can be exported
+// as TimestampName and AgeName metric (see Opts.BuildTimestamp and
+// Opts.BuildAge), using the same namespace and subsystem. Only enable
+// them for build information with an embedded date, as parsing fills in
+// the current time otherwise. Reproducible builds (see
+// buildinfo.EnvironmentInfo.Reproducible) have a fixed date derived from
+// SOURCE_DATE_EPOCH; if that is the Unix epoch, the commit date is used
+// instead. Both metrics are omitted if no build date is known.
+func NewWithOpts(buildInfo *buildinfo.BuildInfo, opts Opts) (prometheus.Collector, error) {
+	fqName, namespace, err := opts.metricName()
+	if err != nil {
+		return nil, err
+	}
+
+	program := opts.program()
+
+	selection := opts.Labels
+	if selection == nil {
+		selection = DefaultLabels()
+	}
+
+	sources := labelSources(buildInfo)
+	labels := make(prometheus.Labels, len(selection)+len(opts.ConstLabels)+len(buildInfo.Extras))
+	for _, src := range sortedKeys(selection) {
+		value, ok := sources[src]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownLabel, src)
+		}
+
+		dst := selection[src]
+		if dst == "" {
+			dst = src
+		}
+
+		if err := addLabel(labels, dst, value); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, k := range sortedKeys(opts.ConstLabels) {
+		if err := addLabel(labels, k, opts.ConstLabels[k]); err != nil {
+			return nil, err
+		}
+	}
+
+	if !opts.OmitExtras {
+		for _, k := range buildInfo.ExtrasKeys() {
+			name := labelName(k)
+			if !validLabelName(name) {
+				return nil, fmt.Errorf("%w: %q (from extra %q)", ErrInvalidLabel, name, k)
+			}
+
+			if _, ok := labels[name]; !ok {
+				labels[name] = buildInfo.Extras[k]
+			}
+		}
+	}
+
+	result := &buildInfoCollector{
+		desc: prometheus.NewDesc(fqName, helpText(selection, program), nil, labels),
+		now:  time.Now,
+	}
+
+	if opts.Info {
+		result.info = fqName
+	}
+
+	if !opts.BuildTimestamp && !opts.BuildAge {
+		return result, nil
+	}
+
+	date, ok := buildDate(buildInfo)
+	if !ok {
+		return result, nil
+	}
+
+	result.date = date
+	if opts.BuildTimestamp {
+		result.timestampDesc = prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, opts.Subsystem, TimestampName),
+			"The Unix timestamp at which "+program+" was built.",
+			nil,
+			opts.ConstLabels,
+		)
+	}
+
+	if opts.BuildAge {
+		result.ageDesc = prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, opts.Subsystem, AgeName),
+			"The number of seconds elapsed since "+program+" was built.",
+			nil,
+			opts.ConstLabels,
+		)
+	}
+
+	return result, nil
+}
+
+// Describe implements the prometheus.Collector interface
+func (c *buildInfoCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+
+	if c.timestampDesc != nil {
+		ch <- c.timestampDesc
+	}
+
+	if c.ageDesc != nil {
+		ch <- c.ageDesc
+	}
+}
+
+// Collect implements the prometheus.Collector interface
+func (c *buildInfoCollector) Collect(ch chan<- prometheus.Metric) {
+	ch <- newConstMetric(c.desc)
+
+	if c.timestampDesc != nil {
+		ch <- newGaugeMetric(c.timestampDesc, float64(c.date.UnixNano())/1e9)
+	}
+
+	if c.ageDesc != nil {
+		ch <- newGaugeMetric(c.ageDesc, c.now().Sub(c.date).Seconds())
+	}
+}
+
+// infoFamily implements the infoCollector interface
+func (c *buildInfoCollector) infoFamily() string {
+	return c.info
+}
+
+// helpText describes the build information metric
+// using the names of the selected builtin labels
+func helpText(selection map[string]string, program string) string {
+	defaultLabels := DefaultLabels()
+	defaults := len(selection) == len(defaultLabels)
+	names := make([]string, 0, len(selection))
+	for src, dst := range selection {
+		if dst == "" {
+			dst = src
+		}
+
+		names = append(names, dst)
+		defaults = defaults && dst == defaultLabels[src]
+	}
+
+	if defaults {
+		return "A metric with a constant '1' value labeled by version, revision, branch, goversion from which " +
+			program + " was built, and the goos and goarch for the build."
+	}
+
+	if len(names) == 0 {
+		return "A metric with a constant '1' value describing the build of " + program + "."
+	}
+
+	sort.Strings(names)
+
+	return "A metric with a constant '1' value labeled by " + strings.Join(names, ", ") +
+		" describing the build of " + program + "."
+}
+
+// buildDate returns the build date of the given information,
+// substituting the commit date for reproducible builds
+// with a build date of the Unix epoch.
+func buildDate(buildInfo *buildinfo.BuildInfo) (time.Time, bool) {
+	e := buildInfo.EnvironmentInfo
+	if e == nil || e.Date.IsZero() {
+		return time.Time{}, false
+	}
+
+	if !e.Reproducible() || e.Date.Unix() > 0 {
+		return e.Date, true
+	}
+
+	if v := buildInfo.VersionInfo; v != nil && v.CommitDate != nil && v.CommitDate.Unix() > 0 {
+		return *v.CommitDate, true
+	}
+
+	return time.Time{}, false
+}
+
+// labelSources returns the values of all builtin labels
+func labelSources(buildInfo *buildinfo.BuildInfo) map[string]string {
+	goVersion, goOS, goArch := buildinfo.GoVersion, buildinfo.GoOS, buildinfo.GoArch
+	if r := buildInfo.Runtime; r != nil {
+		goVersion, goOS, goArch = r.GoVersion, r.GoOS, r.GoArch
+	}
+
+	result := map[string]string{
+		LabelGoVersion: goVersion,
+		LabelGoOS:      goOS,
+		LabelGoArch:    goArch,
+	}
+
+	v := buildInfo.VersionInfo
+	if v == nil {
+		v = &buildinfo.VersionInfo{}
+	}
+
+	result[LabelVersion] = v.Version
+	result[LabelRevision] = v.Revision
+	result[LabelBranch] = v.Branch
+	result[LabelDirty] = strconv.FormatBool(v.Dirty)
+
+	e := buildInfo.EnvironmentInfo
+	if e == nil {
+		e = &buildinfo.EnvironmentInfo{}
+	}
+
+	result[LabelUser] = e.User
+	result[LabelHost] = e.Host
+
+	return result
+}
+
+// addLabel validates the given name and adds the
+// label unless another one with the same name exists
+func addLabel(labels prometheus.Labels, name, value string) error {
+	if !validLabelName(name) {
+		return fmt.Errorf("%w: %q", ErrInvalidLabel, name)
+	}
+
+	if _, ok := labels[name]; ok {
+		return fmt.Errorf("%w: %q", ErrDuplicateLabel, name)
+	}
+
+	labels[name] = value
+
+	return nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// labelName converts the given value into a valid label name
+// by replacing all unsupported characters with underscores.
+func labelName(s string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+
+	return name
+}
+
+// validLabelName reports whether the given value
+// satisfies the label naming rules
+func validLabelName(s string) bool {
+	return s != "" && !strings.HasPrefix(s, "__") && labelName(s) == s
+}
+
+// validMetricName reports whether the given value
+// satisfies the metric naming rules
+func validMetricName(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for n, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_', r == ':':
+		case r >= '0' && r <= '9' && n > 0:
+		default:
+			return false
+		}
+	}
+
+	return true
 }
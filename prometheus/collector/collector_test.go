@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 
 	"gotest.tools/v3/assert"
@@ -36,3 +37,321 @@ test_build_info{branch="trunk",` + golabels + `,revision="HEAD",version="1.2.3"}
 
 	assert.NilError(t, testutil.CollectAndCompare(got, want))
 }
+
+func TestNewExtras(t *testing.T) {
+	golabels := fmt.Sprintf("goarch=%q,goos=%q,goversion=%q",
+		runtime.GOARCH, runtime.GOOS, runtime.Version())
+	bi := buildinfo.New()
+	bi.Extras = map[string]string{
+		"release-channel": "stable",
+		"1sku":            "enterprise",
+		"version":         "ignored",
+	}
+	got := New(bi, "test")
+	want := strings.NewReader(`# HELP test_build_info A metric with a constant '1' value labeled by version, revision, branch, goversion from which test was built, and the goos and goarch for the build.
+# TYPE test_build_info gauge
+test_build_info{_1sku="enterprise",branch="trunk",` + golabels + `,release_channel="stable",revision="HEAD",version="0.0.0"} 1
+`)
+
+	assert.NilError(t, testutil.CollectAndCompare(got, want))
+}
+
+func TestNewWithOpts(t *testing.T) {
+	golabels := fmt.Sprintf("goarch=%q,goos=%q,goversion=%q",
+		runtime.GOARCH, runtime.GOOS, runtime.Version())
+	bi := buildinfo.NewBuildInfo(
+		&buildinfo.VersionInfo{
+			Version:  "1.2.3",
+			Revision: "deadbeef",
+			Branch:   "main",
+			Dirty:    true,
+		},
+		&buildinfo.EnvironmentInfo{
+			User: "root",
+			Host: "localhost",
+			Date: time.Unix(0, 0),
+		},
+	)
+	bi.Extras = map[string]string{
+		"channel": "stable",
+	}
+
+	type testCase struct {
+		opts       Opts
+		wantMetric string
+		wantLabels string
+		wantHelp   string
+	}
+
+	testCases := map[string]testCase{
+		"sanitized namespace": {
+			opts:       Opts{Namespace: "my-app"},
+			wantMetric: "my_app_build_info",
+			wantLabels: `branch="main",channel="stable",` + golabels + `,revision="deadbeef",version="1.2.3"`,
+		},
+		"no namespace": {
+			opts:       Opts{Program: "test"},
+			wantMetric: "build_info",
+			wantLabels: `branch="main",channel="stable",` + golabels + `,revision="deadbeef",version="1.2.3"`,
+		},
+		"subsystem and name": {
+			opts:       Opts{Namespace: "test", Subsystem: "app", Name: "version_info", OmitExtras: true},
+			wantMetric: "test_app_version_info",
+			wantLabels: `branch="main",` + golabels + `,revision="deadbeef",version="1.2.3"`,
+		},
+		"label selection": {
+			opts: Opts{
+				Namespace: "test",
+				Labels: map[string]string{
+					LabelVersion:  "",
+					LabelRevision: "commit",
+					LabelDirty:    "",
+					LabelUser:     "build_user",
+					LabelHost:     "build_host",
+				},
+				ConstLabels: map[string]string{"team": "platform"},
+			},
+			wantMetric: "test_build_info",
+			wantLabels: `build_host="localhost",build_user="root",channel="stable",commit="deadbeef",dirty="true",team="platform",version="1.2.3"`,
+			wantHelp:   "A metric with a constant '1' value labeled by build_host, build_user, commit, dirty, version describing the build of test.",
+		},
+		"renamed default labels": {
+			opts: Opts{
+				Namespace: "test",
+				Labels: map[string]string{
+					LabelVersion:   "app_version",
+					LabelRevision:  "",
+					LabelBranch:    "",
+					LabelGoVersion: "",
+					LabelGoOS:      "",
+					LabelGoArch:    "",
+				},
+				OmitExtras: true,
+			},
+			wantMetric: "test_build_info",
+			wantLabels: `app_version="1.2.3",branch="main",` + golabels + `,revision="deadbeef"`,
+			wantHelp:   "A metric with a constant '1' value labeled by app_version, branch, goarch, goos, goversion, revision describing the build of test.",
+		},
+		"no builtin labels": {
+			opts:       Opts{Namespace: "test", Labels: map[string]string{}, OmitExtras: true},
+			wantMetric: "test_build_info",
+			wantHelp:   "A metric with a constant '1' value describing the build of test.",
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			got, err := NewWithOpts(bi, tc.opts)
+			assert.NilError(t, err)
+
+			help := tc.wantHelp
+			if help == "" {
+				help = `A metric with a constant '1' value labeled by version, revision, branch, goversion from which ` +
+					tc.opts.Program + tc.opts.Namespace + ` was built, and the goos and goarch for the build.`
+			}
+
+			labels := ""
+			if tc.wantLabels != "" {
+				labels = `{` + tc.wantLabels + `}`
+			}
+
+			want := `# HELP ` + tc.wantMetric + ` ` + help + `
+# TYPE ` + tc.wantMetric + ` gauge
+` + tc.wantMetric + labels + ` 1
+`
+			assert.NilError(t, testutil.CollectAndCompare(got, strings.NewReader(want)))
+		})
+	}
+}
+
+func TestNewWithOptsError(t *testing.T) {
+	type testCase struct {
+		opts Opts
+		want error
+	}
+
+	testCases := map[string]testCase{
+		"subsystem": {
+			opts: Opts{Namespace: "test", Subsystem: "my-app"},
+			want: ErrInvalidName,
+		},
+		"name": {
+			opts: Opts{Name: "1build_info"},
+			want: ErrInvalidName,
+		},
+		"unknown label": {
+			opts: Opts{Labels: map[string]string{"date": ""}},
+			want: ErrUnknownLabel,
+		},
+		"invalid label": {
+			opts: Opts{Labels: map[string]string{LabelVersion: "build-version"}},
+			want: ErrInvalidLabel,
+		},
+		"reserved label": {
+			opts: Opts{ConstLabels: map[string]string{"__name__": "test"}},
+			want: ErrInvalidLabel,
+		},
+		"duplicate label": {
+			opts: Opts{ConstLabels: map[string]string{LabelVersion: "test"}},
+			want: ErrDuplicateLabel,
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			_, err := NewWithOpts(buildinfo.New(), tc.opts)
+			assert.ErrorIs(t, err, tc.want)
+		})
+	}
+}
+
+func TestNewWithOptsExtras(t *testing.T) {
+	type testCase struct {
+		have      string
+		wantLabel string
+		wantError bool
+	}
+
+	testCases := map[string]testCase{
+		"leading digit": {
+			have:      "1-foo",
+			wantLabel: "_1_foo",
+		},
+		"dotted": {
+			have:      "ci.pipeline",
+			wantLabel: "ci_pipeline",
+		},
+		"reserved": {
+			have:      "__name__",
+			wantError: true,
+		},
+		"reserved after sanitizing": {
+			have:      "--foo",
+			wantError: true,
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			bi := buildinfo.New()
+			bi.Extras = map[string]string{tc.have: "test"}
+
+			got, err := NewWithOpts(bi, Opts{Namespace: "test"})
+			if tc.wantError {
+				assert.ErrorIs(t, err, ErrInvalidLabel)
+
+				reg := prometheus.NewPedanticRegistry()
+				assert.Assert(t, reg.Register(New(bi, "test")) != nil)
+				return
+			}
+
+			assert.NilError(t, err)
+
+			reg := prometheus.NewPedanticRegistry()
+			assert.NilError(t, reg.Register(got))
+
+			families, err := reg.Gather()
+			assert.NilError(t, err)
+
+			var found bool
+			for _, l := range families[0].GetMetric()[0].GetLabel() {
+				found = found || (l.GetName() == tc.wantLabel && l.GetValue() == "test")
+			}
+			assert.Assert(t, found, "label %q missing", tc.wantLabel)
+		})
+	}
+}
+
+func TestNewRegister(t *testing.T) {
+	reg := prometheus.NewPedanticRegistry()
+	assert.NilError(t, reg.Register(New(buildinfo.New(), "my-app")))
+
+	families, err := reg.Gather()
+	assert.NilError(t, err)
+	assert.Equal(t, len(families), 1)
+	assert.Equal(t, families[0].GetName(), "my_app_build_info")
+}
+
+func TestNewWithOptsTimestamp(t *testing.T) {
+	commitDate := time.Unix(1700000000, 0)
+	now := time.Unix(1700003600, 500000000)
+
+	type testCase struct {
+		have *buildinfo.EnvironmentInfo
+		want string
+	}
+
+	testCases := map[string]testCase{
+		"regular": {
+			have: &buildinfo.EnvironmentInfo{
+				User: "root",
+				Host: "localhost",
+				Date: time.Unix(1700001800, 0),
+			},
+			want: `# HELP test_build_age_seconds The number of seconds elapsed since test was built.
+# TYPE test_build_age_seconds gauge
+test_build_age_seconds 1800.5
+# HELP test_build_timestamp_seconds The Unix timestamp at which test was built.
+# TYPE test_build_timestamp_seconds gauge
+test_build_timestamp_seconds 1.7000018e+09
+`,
+		},
+		"reproducible": {
+			have: &buildinfo.EnvironmentInfo{
+				User: buildinfo.ReproduciblePlaceholder,
+				Host: buildinfo.ReproduciblePlaceholder,
+				Date: time.Unix(1600000000, 0),
+			},
+			want: `# HELP test_build_age_seconds The number of seconds elapsed since test was built.
+# TYPE test_build_age_seconds gauge
+test_build_age_seconds 1.000036005e+08
+# HELP test_build_timestamp_seconds The Unix timestamp at which test was built.
+# TYPE test_build_timestamp_seconds gauge
+test_build_timestamp_seconds 1.6e+09
+`,
+		},
+		"reproducible epoch": {
+			have: &buildinfo.EnvironmentInfo{
+				User: buildinfo.ReproduciblePlaceholder,
+				Host: buildinfo.ReproduciblePlaceholder,
+				Date: time.Unix(0, 0),
+			},
+			want: `# HELP test_build_age_seconds The number of seconds elapsed since test was built.
+# TYPE test_build_age_seconds gauge
+test_build_age_seconds 3600.5
+# HELP test_build_timestamp_seconds The Unix timestamp at which test was built.
+# TYPE test_build_timestamp_seconds gauge
+test_build_timestamp_seconds 1.7e+09
+`,
+		},
+		"unknown": {
+			have: &buildinfo.EnvironmentInfo{
+				User: "root",
+				Host: "localhost",
+			},
+			want: ``,
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			bi := buildinfo.NewBuildInfo(&buildinfo.VersionInfo{CommitDate: &commitDate}, tc.have)
+			got, err := NewWithOpts(bi, Opts{Namespace: "test", BuildTimestamp: true, BuildAge: true})
+			assert.NilError(t, err)
+
+			got.(*buildInfoCollector).now = func() time.Time { return now }
+
+			assert.NilError(t, testutil.CollectAndCompare(got, strings.NewReader(tc.want),
+				"test_build_timestamp_seconds", "test_build_age_seconds"))
+		})
+	}
+
+	bi := buildinfo.NewBuildInfo(nil, &buildinfo.EnvironmentInfo{
+		User: buildinfo.ReproduciblePlaceholder,
+		Host: buildinfo.ReproduciblePlaceholder,
+		Date: time.Unix(0, 0),
+	})
+	got, err := NewWithOpts(bi, Opts{Namespace: "test", BuildTimestamp: true, BuildAge: true})
+	assert.NilError(t, err)
+	assert.Equal(t, testutil.CollectAndCount(got), 1)
+}
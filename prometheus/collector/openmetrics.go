@@ -0,0 +1,245 @@
+package collector
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/common/expfmt"
+)
+
+const (
+	// infoSuffix is the mandatory suffix of OpenMetrics info samples
+	infoSuffix = "_info"
+	// encodingGzip is the content encoding applied to rewritten responses
+	encodingGzip = "gzip"
+)
+
+// infoCollector is implemented by collectors
+// created with Opts.Info
+type infoCollector interface {
+	// infoFamily returns the name of the metric family
+	// to expose as info family, or an empty string
+	infoFamily() string
+}
+
+// HandlerFor wraps promhttp.HandlerFor for the provided Gatherer.
+// If opts.EnableOpenMetrics is set and the scrape negotiates the
+// OpenMetrics format, the build information metrics of the given
+// collectors created with Opts.Info are exposed using the info type.
+// All other metrics, formats and aspects of the response are left
+// to promhttp, which exposes the build information as gauge.
+// Rewritten responses are compressed using gzip, unless disabled
+// via opts.DisableCompression or opts.OfferedCompressions.
+func HandlerFor(reg prometheus.Gatherer, opts promhttp.HandlerOpts, collectors ...prometheus.Collector) http.Handler {
+	handler := promhttp.HandlerFor(reg, opts)
+	families := infoFamilies(collectors)
+	if !opts.EnableOpenMetrics || len(families) == 0 {
+		return handler
+	}
+
+	compress := !opts.DisableCompression && offersGzip(opts.OfferedCompressions)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		format := expfmt.NegotiateIncludingOpenMetrics(r.Header)
+		if format.FormatType() != expfmt.TypeOpenMetrics {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		// the exposition is rewritten before compression
+		inner := r.Clone(r.Context())
+		inner.Header.Set("Accept-Encoding", "identity")
+
+		iw := &infoWriter{
+			ResponseWriter: w,
+			families:       families,
+			compress:       compress && acceptsGzip(r.Header),
+		}
+		defer iw.Close()
+
+		handler.ServeHTTP(iw, inner)
+	})
+}
+
+// infoFamilies returns the names of the
+// info families of the given collectors
+func infoFamilies(collectors []prometheus.Collector) map[string]struct{} {
+	result := make(map[string]struct{}, len(collectors))
+	for _, c := range collectors {
+		if ic, ok := c.(infoCollector); ok && ic.infoFamily() != "" {
+			result[ic.infoFamily()] = struct{}{}
+		}
+	}
+
+	return result
+}
+
+// offersGzip reports whether gzip is part of the
+// given compressions; an empty set implies the
+// promhttp defaults, which include gzip.
+func offersGzip(offers []promhttp.Compression) bool {
+	if len(offers) == 0 {
+		return true
+	}
+
+	for _, c := range offers {
+		if c == promhttp.Gzip {
+			return true
+		}
+	}
+
+	return false
+}
+
+// acceptsGzip reports whether the request accepts gzip encoded responses
+func acceptsGzip(h http.Header) bool {
+	for _, v := range h.Values("Accept-Encoding") {
+		for _, enc := range strings.Split(v, ",") {
+			name, params, _ := strings.Cut(enc, ";")
+			if strings.TrimSpace(name) != encodingGzip {
+				continue
+			}
+
+			q := 1.0
+			for _, param := range strings.Split(params, ";") {
+				if k, v, _ := strings.Cut(strings.TrimSpace(param), "="); k == "q" {
+					q, _ = strconv.ParseFloat(v, 64)
+				}
+			}
+
+			return q > 0
+		}
+	}
+
+	return false
+}
+
+// infoWriter converts the OpenMetrics gauge families with the given
+// names into info families while the exposition is written. Responses
+// other than 200 OK are passed on as-is.
+type infoWriter struct {
+	http.ResponseWriter
+
+	families map[string]struct{}
+	compress bool
+
+	started bool
+	bypass  bool
+	out     *gzip.Writer
+	line    []byte
+	family  string
+}
+
+// WriteHeader implements the http.ResponseWriter interface
+func (w *infoWriter) WriteHeader(code int) {
+	if w.started {
+		return
+	}
+
+	w.started = true
+	w.bypass = code != http.StatusOK
+	if !w.bypass && w.compress {
+		w.Header().Set("Content-Encoding", encodingGzip)
+		w.Header().Del("Content-Length")
+		w.out = gzip.NewWriter(w.ResponseWriter)
+	}
+
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write implements the io.Writer interface
+func (w *infoWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if w.bypass {
+		return w.ResponseWriter.Write(p)
+	}
+
+	w.line = append(w.line, p...)
+	for {
+		i := bytes.IndexByte(w.line, '\n')
+		if i < 0 {
+			break
+		}
+
+		if err := w.emit(w.infoLine(string(w.line[:i])) + "\n"); err != nil {
+			return 0, err
+		}
+		w.line = w.line[i+1:]
+	}
+
+	return len(p), nil
+}
+
+// Close flushes any remaining data
+func (w *infoWriter) Close() error {
+	if len(w.line) > 0 {
+		if err := w.emit(w.infoLine(string(w.line))); err != nil {
+			return err
+		}
+		w.line = nil
+	}
+
+	if w.out != nil {
+		return w.out.Close()
+	}
+
+	return nil
+}
+
+func (w *infoWriter) emit(s string) (err error) {
+	if w.out != nil {
+		_, err = w.out.Write([]byte(s))
+	} else {
+		_, err = w.ResponseWriter.Write([]byte(s))
+	}
+
+	return
+}
+
+// infoLine converts the given line of the exposition,
+// keeping track of the family it belongs to
+func (w *infoWriter) infoLine(line string) string {
+	if strings.HasPrefix(line, "# ") {
+		w.family = ""
+
+		fields := strings.SplitN(line, " ", 4)
+		if len(fields) < 3 {
+			return line
+		}
+
+		if _, ok := w.families[fields[2]]; !ok {
+			return line
+		}
+
+		w.family = fields[2]
+		base := strings.TrimSuffix(w.family, infoSuffix)
+		switch {
+		case fields[1] == "HELP":
+			return "# HELP " + base + strings.TrimPrefix(line, "# HELP "+w.family)
+		case fields[1] == "TYPE" && len(fields) == 4 && fields[3] == "gauge":
+			return "# TYPE " + base + " info"
+		}
+
+		return line
+	}
+
+	if w.family == "" {
+		return line
+	}
+
+	if strings.HasPrefix(line, w.family+"{") || strings.HasPrefix(line, w.family+" ") {
+		if i := strings.LastIndexByte(line, ' '); i > 0 {
+			return line[:i] + " 1"
+		}
+	}
+
+	return line
+}
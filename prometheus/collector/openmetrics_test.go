@@ -0,0 +1,165 @@
+package collector
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
+	"gotest.tools/v3/assert"
+
+	"github.com/UiP9AV6Y/buildinfo"
+)
+
+func TestHandlerFor(t *testing.T) {
+	golabels := fmt.Sprintf("goarch=%q,goos=%q,goversion=%q",
+		runtime.GOARCH, runtime.GOOS, runtime.Version())
+	bi := buildinfo.NewBuildInfo(
+		&buildinfo.VersionInfo{
+			Version:  "1.2.3",
+			Revision: "deadbeef",
+			Branch:   "main",
+		},
+		&buildinfo.EnvironmentInfo{
+			User: "root",
+			Host: "localhost",
+		},
+	)
+
+	c, err := NewWithOpts(bi, Opts{Program: "test", Namespace: "omtest", Info: true})
+	assert.NilError(t, err)
+
+	reg := prometheus.NewPedanticRegistry()
+	reg.MustRegister(c)
+
+	openMetrics := promhttp.HandlerOpts{EnableOpenMetrics: true}
+	omType := "application/openmetrics-text; version=1.0.0; charset=utf-8; escaping=values"
+	textType := "text/plain; version=0.0.4; charset=utf-8; escaping=values"
+	help := "A metric with a constant '1' value labeled by version, revision, branch, goversion from which " +
+		"test was built, and the goos and goarch for the build."
+	labels := `{branch="main",` + golabels + `,revision="deadbeef",version="1.2.3"}`
+	info := "# HELP omtest_build " + help + "\n" +
+		"# TYPE omtest_build info\n" +
+		"omtest_build_info" + labels + " 1\n" +
+		"# EOF\n"
+	gauge := "# HELP omtest_build_info " + help + "\n" +
+		"# TYPE omtest_build_info gauge\n" +
+		"omtest_build_info" + labels + " 1.0\n" +
+		"# EOF\n"
+	text := "# HELP omtest_build_info " + help + "\n" +
+		"# TYPE omtest_build_info gauge\n" +
+		"omtest_build_info" + labels + " 1\n"
+
+	type testCase struct {
+		opts        promhttp.HandlerOpts
+		collectors  []prometheus.Collector
+		accept      string
+		encoding    string
+		wantType    string
+		wantContent string
+	}
+
+	testCases := map[string]testCase{
+		"openmetrics": {
+			opts:        openMetrics,
+			collectors:  []prometheus.Collector{c},
+			accept:      "application/openmetrics-text; version=1.0.0",
+			wantType:    omType,
+			wantContent: info,
+		},
+		"openmetrics gzip": {
+			opts:        openMetrics,
+			collectors:  []prometheus.Collector{c},
+			accept:      "application/openmetrics-text; version=1.0.0",
+			encoding:    "gzip",
+			wantType:    omType,
+			wantContent: info,
+		},
+		"openmetrics uncompressed": {
+			opts:        promhttp.HandlerOpts{EnableOpenMetrics: true, DisableCompression: true},
+			collectors:  []prometheus.Collector{c},
+			accept:      "application/openmetrics-text; version=1.0.0",
+			wantType:    omType,
+			wantContent: info,
+		},
+		"openmetrics disabled": {
+			collectors:  []prometheus.Collector{c},
+			accept:      "application/openmetrics-text; version=1.0.0",
+			wantType:    textType,
+			wantContent: text,
+		},
+		"unmarked collector": {
+			opts:        openMetrics,
+			collectors:  []prometheus.Collector{New(bi, "omtest")},
+			accept:      "application/openmetrics-text; version=1.0.0",
+			wantType:    omType,
+			wantContent: gauge,
+		},
+		"text": {
+			opts:        openMetrics,
+			collectors:  []prometheus.Collector{c},
+			accept:      "text/plain",
+			wantType:    textType,
+			wantContent: text,
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			handler := HandlerFor(reg, tc.opts, tc.collectors...)
+			req := httptest.NewRequest("GET", "/metrics", nil)
+			req.Header.Set("Accept", tc.accept)
+			if tc.encoding != "" {
+				req.Header.Set("Accept-Encoding", tc.encoding)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			var body io.Reader = rec.Result().Body
+			if tc.encoding != "" && !tc.opts.DisableCompression {
+				assert.Equal(t, rec.Header().Get("Content-Encoding"), tc.encoding)
+				body, err = gzip.NewReader(body)
+				assert.NilError(t, err)
+			} else {
+				assert.Equal(t, rec.Header().Get("Content-Encoding"), "")
+			}
+
+			got, err := io.ReadAll(body)
+			assert.NilError(t, err)
+			assert.Equal(t, rec.Code, 200)
+			assert.Equal(t, rec.Header().Get("Content-Type"), tc.wantType)
+			assert.Equal(t, string(got), tc.wantContent)
+		})
+	}
+}
+
+func TestHandlerForError(t *testing.T) {
+	c, err := NewWithOpts(buildinfo.New(), Opts{Namespace: "omtest", Info: true})
+	assert.NilError(t, err)
+
+	reg := prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		return nil, errors.New("gather failure")
+	})
+
+	handler := HandlerFor(reg, promhttp.HandlerOpts{EnableOpenMetrics: true}, c)
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	req.Header.Set("Accept", "application/openmetrics-text; version=1.0.0")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, rec.Code, 500)
+	assert.Equal(t, rec.Header().Get("Content-Encoding"), "")
+}
+
+func TestNewWithOptsInfoSuffix(t *testing.T) {
+	_, err := NewWithOpts(buildinfo.New(), Opts{Namespace: "test", Name: "build", Info: true})
+	assert.ErrorIs(t, err, ErrInvalidName)
+}
@@ -0,0 +1,115 @@
+package collector
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/UiP9AV6Y/buildinfo"
+)
+
+// ComponentLabel is the label containing the component
+// name of the metrics exported by NewRegistry
+const ComponentLabel = "component"
+
+// registryCollector exports the build information
+// of all components registered at collection time
+type registryCollector struct {
+	desc    *prometheus.Desc
+	reg     *buildinfo.Registry
+	sources []string
+	info    string
+}
+
+// NewRegistry returns a collector that exports one build information
+// series per component of the given registry (buildinfo.DefaultRegistry
+// if nil), labeled by ComponentLabel. Components registered after the
+// creation of the collector are included as well. The metric name and
+// labels are configured like NewWithOpts; extras as well as the build
+// timestamp and -age metrics are not supported.
+func NewRegistry(reg *buildinfo.Registry, opts Opts) (prometheus.Collector, error) {
+	if reg == nil {
+		reg = buildinfo.DefaultRegistry
+	}
+
+	fqName, _, err := opts.metricName()
+	if err != nil {
+		return nil, err
+	}
+
+	program := opts.program()
+
+	selection := opts.Labels
+	if selection == nil {
+		selection = DefaultLabels()
+	}
+
+	known := labelSources(&buildinfo.BuildInfo{})
+	labels := prometheus.Labels{}
+	if err := addLabel(labels, ComponentLabel, ""); err != nil {
+		return nil, err
+	}
+
+	sources := sortedKeys(selection)
+	variableLabels := []string{ComponentLabel}
+	for _, src := range sources {
+		if _, ok := known[src]; !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownLabel, src)
+		}
+
+		dst := selection[src]
+		if dst == "" {
+			dst = src
+		}
+
+		if err := addLabel(labels, dst, ""); err != nil {
+			return nil, err
+		}
+
+		variableLabels = append(variableLabels, dst)
+	}
+
+	for _, k := range sortedKeys(opts.ConstLabels) {
+		if err := addLabel(labels, k, opts.ConstLabels[k]); err != nil {
+			return nil, err
+		}
+	}
+
+	help := "A metric with a constant '1' value labeled by component, version, revision, branch, goversion " +
+		"of the components " + program + " was built from, and the goos and goarch for the build."
+	result := &registryCollector{
+		desc:    prometheus.NewDesc(fqName, help, variableLabels, opts.ConstLabels),
+		reg:     reg,
+		sources: sources,
+	}
+
+	if opts.Info {
+		result.info = fqName
+	}
+
+	return result, nil
+}
+
+// Describe implements the prometheus.Collector interface
+func (c *registryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// infoFamily implements the infoCollector interface
+func (c *registryCollector) infoFamily() string {
+	return c.info
+}
+
+// Collect implements the prometheus.Collector interface
+func (c *registryCollector) Collect(ch chan<- prometheus.Metric) {
+	for component, info := range c.reg.All() {
+		values := labelSources(info)
+		labelValues := make([]string, 0, len(c.sources)+1)
+		labelValues = append(labelValues, component)
+		for _, src := range c.sources {
+			labelValues = append(labelValues, values[src])
+		}
+
+		ch <- newConstMetric(c.desc, labelValues...)
+	}
+}
@@ -0,0 +1,68 @@
+package collector
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"gotest.tools/v3/assert"
+
+	"github.com/UiP9AV6Y/buildinfo"
+)
+
+func TestNewRegistry(t *testing.T) {
+	reg := buildinfo.NewRegistry()
+	reg.MustRegister("core", &buildinfo.BuildInfo{
+		VersionInfo: &buildinfo.VersionInfo{Version: "1.2.3", Revision: "deadbeef", Branch: "main"},
+	})
+
+	got, err := NewRegistry(reg, Opts{
+		Namespace: "test",
+		Labels: map[string]string{
+			LabelVersion:  "",
+			LabelRevision: "commit",
+		},
+		ConstLabels: map[string]string{"team": "platform"},
+	})
+	assert.NilError(t, err)
+
+	reg.MustRegister("plugin", &buildinfo.BuildInfo{
+		VersionInfo: &buildinfo.VersionInfo{Version: "0.1.0", Revision: "cafebabe"},
+	})
+
+	want := `# HELP test_build_info A metric with a constant '1' value labeled by component, version, revision, branch, goversion of the components test was built from, and the goos and goarch for the build.
+# TYPE test_build_info gauge
+test_build_info{commit="deadbeef",component="core",team="platform",version="1.2.3"} 1
+test_build_info{commit="cafebabe",component="plugin",team="platform",version="0.1.0"} 1
+`
+	assert.NilError(t, testutil.CollectAndCompare(got, strings.NewReader(want)))
+}
+
+func TestNewRegistryError(t *testing.T) {
+	type testCase struct {
+		opts Opts
+		want error
+	}
+
+	testCases := map[string]testCase{
+		"name": {
+			opts: Opts{Namespace: "test", Subsystem: "my-app"},
+			want: ErrInvalidName,
+		},
+		"unknown label": {
+			opts: Opts{Labels: map[string]string{"date": ""}},
+			want: ErrUnknownLabel,
+		},
+		"component clash": {
+			opts: Opts{Labels: map[string]string{LabelBranch: ComponentLabel}},
+			want: ErrDuplicateLabel,
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			_, err := NewRegistry(buildinfo.NewRegistry(), tc.opts)
+			assert.ErrorIs(t, err, tc.want)
+		})
+	}
+}
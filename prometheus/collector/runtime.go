@@ -0,0 +1,99 @@
+package collector
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/UiP9AV6Y/buildinfo"
+)
+
+type runtimeCollector struct {
+	settingsDesc *prometheus.Desc
+	depDesc      *prometheus.Desc
+	deps         []*buildinfo.Dependency
+}
+
+// NewRuntime returns a collector that exports metrics about the
+// Golang build settings and module dependencies of the running
+// binary. If the given data contains no runtime information, it is
+// retrieved using buildinfo.NewRuntimeInfo.
+// Like with New, the program is used as (sanitized) namespace.
+func NewRuntime(buildInfo *buildinfo.BuildInfo, program string) prometheus.Collector {
+	info := buildInfo.Runtime
+	if info == nil {
+		info = buildinfo.NewRuntimeInfo()
+	}
+
+	namespace := program
+	if namespace != "" {
+		namespace = labelName(namespace)
+	}
+
+	settingsHelp := "A metric with a constant '1' value labeled by the compiler, build tags, cgo and trimpath " +
+		"settings and the architecture level with which " + program + " was built."
+	settingsLabels := prometheus.Labels{
+		"compiler":    info.Compiler,
+		"tags":        info.Tags(),
+		"cgo":         strconv.FormatBool(info.CGOEnabled),
+		"trimpath":    strconv.FormatBool(info.TrimPath),
+		"goarchlevel": info.ArchLevel,
+	}
+	settingsDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "build_settings_info"),
+		settingsHelp,
+		nil,
+		settingsLabels,
+	)
+
+	depHelp := "A metric with a constant '1' value labeled by path, version, checksum and replacement path " +
+		"of the modules " + program + " was built with."
+	depDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "build_dependency_info"),
+		depHelp,
+		[]string{"path", "version", "checksum", "replace"},
+		nil,
+	)
+
+	return &runtimeCollector{
+		settingsDesc: settingsDesc,
+		depDesc:      depDesc,
+		deps:         info.Deps,
+	}
+}
+
+// Describe implements the prometheus.Collector interface
+func (c *runtimeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.settingsDesc
+	ch <- c.depDesc
+}
+
+// Collect implements the prometheus.Collector interface
+func (c *runtimeCollector) Collect(ch chan<- prometheus.Metric) {
+	ch <- newConstMetric(c.settingsDesc)
+
+	for _, d := range c.deps {
+		// version and checksum of replaced modules
+		// describe the replacement module
+		var replace string
+		version, sum := d.Version, d.Sum
+		if d.Replace != nil {
+			replace, version, sum = d.Replace.Path, d.Replace.Version, d.Replace.Sum
+		}
+
+		ch <- newConstMetric(c.depDesc, d.Path, version, sum, replace)
+	}
+}
+
+func newConstMetric(desc *prometheus.Desc, labelValues ...string) prometheus.Metric {
+	return newGaugeMetric(desc, 1, labelValues...)
+}
+
+func newGaugeMetric(desc *prometheus.Desc, value float64, labelValues ...string) prometheus.Metric {
+	m, err := prometheus.NewConstMetric(desc, prometheus.GaugeValue, value, labelValues...)
+	if err != nil {
+		return prometheus.NewInvalidMetric(desc, err)
+	}
+
+	return m
+}
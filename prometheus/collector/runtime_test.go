@@ -0,0 +1,70 @@
+package collector
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/UiP9AV6Y/buildinfo"
+)
+
+func TestNewRuntime(t *testing.T) {
+	bi := buildinfo.New()
+	bi.Runtime = &buildinfo.RuntimeInfo{
+		GoVersion: "go1.21.5",
+		GoOS:      "linux",
+		GoArch:    "amd64",
+		ArchLevel: "v3",
+		Compiler:  "gc",
+		BuildTags: []string{"netgo", "osusergo"},
+		TrimPath:  true,
+		Deps: []*buildinfo.Dependency{
+			{
+				Path:    "example.com/dep",
+				Version: "v0.1.0",
+				Sum:     "h1:dep",
+			},
+			{
+				Path:    "example.com/fork",
+				Version: "v1.0.0",
+				Sum:     "h1:fork",
+				Replace: &buildinfo.Dependency{
+					Path:    "example.com/forked",
+					Version: "v1.0.1",
+					Sum:     "h1:forked",
+				},
+			},
+		},
+	}
+	got := NewRuntime(bi, "test")
+	want := strings.NewReader(`# HELP test_build_dependency_info A metric with a constant '1' value labeled by path, version, checksum and replacement path of the modules test was built with.
+# TYPE test_build_dependency_info gauge
+test_build_dependency_info{checksum="h1:dep",path="example.com/dep",replace="",version="v0.1.0"} 1
+test_build_dependency_info{checksum="h1:forked",path="example.com/fork",replace="example.com/forked",version="v1.0.1"} 1
+# HELP test_build_settings_info A metric with a constant '1' value labeled by the compiler, build tags, cgo and trimpath settings and the architecture level with which test was built.
+# TYPE test_build_settings_info gauge
+test_build_settings_info{cgo="false",compiler="gc",goarchlevel="v3",tags="netgo,osusergo",trimpath="true"} 1
+`)
+
+	assert.NilError(t, testutil.CollectAndCompare(got, want))
+}
+
+func TestNewRuntimeRegister(t *testing.T) {
+	bi := buildinfo.New()
+	bi.Runtime = &buildinfo.RuntimeInfo{
+		Deps: []*buildinfo.Dependency{{Path: "example.com/dep", Version: "v0.1.0"}},
+	}
+
+	reg := prometheus.NewPedanticRegistry()
+	assert.NilError(t, reg.Register(NewRuntime(bi, "my-app")))
+
+	families, err := reg.Gather()
+	assert.NilError(t, err)
+	assert.Equal(t, len(families), 2)
+	assert.Equal(t, families[0].GetName(), "my_app_build_dependency_info")
+	assert.Equal(t, families[1].GetName(), "my_app_build_settings_info")
+}
@@ -0,0 +1,85 @@
+// Package push sends build information metrics to a Prometheus Pushgateway,
+// for short-lived jobs which exit before they can be scraped.
+package push
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/push"
+
+	"github.com/UiP9AV6Y/buildinfo"
+	"github.com/UiP9AV6Y/buildinfo/prometheus/collector"
+)
+
+// Opts contains the settings of Push
+type Opts struct {
+	// Job is the name of the job label. If empty,
+	// the collector program (or namespace) is used.
+	Job string
+	// Grouping contains additional grouping key labels
+	Grouping map[string]string
+	// Collector configures the pushed metrics (see collector.NewWithOpts)
+	Collector collector.Opts
+	// Add uses POST semantics, replacing only the metrics with the same
+	// name in the group. Otherwise PUT semantics are used, replacing all
+	// metrics of the group.
+	Add bool
+	// Timeout limits the duration of the request,
+	// in addition to the given context. Zero means no timeout.
+	Timeout time.Duration
+	// Client performs the request. If nil, http.DefaultClient is used.
+	Client push.HTTPDoer
+}
+
+// Push sends the metrics of a collector built from the given
+// information to the Pushgateway with the given URL. An error
+// is returned if the collector can not be created or the
+// request fails or is rejected.
+func Push(ctx context.Context, url string, info *buildinfo.BuildInfo, opts Opts) error {
+	c, err := collector.NewWithOpts(info, opts.Collector)
+	if err != nil {
+		return err
+	}
+
+	job := opts.Job
+	if job == "" {
+		job = opts.Collector.Program
+	}
+	if job == "" {
+		job = opts.Collector.Namespace
+	}
+
+	pusher := push.New(url, job).Collector(c)
+	for _, k := range sortedKeys(opts.Grouping) {
+		pusher = pusher.Grouping(k, opts.Grouping[k])
+	}
+
+	if opts.Client != nil {
+		pusher = pusher.Client(opts.Client)
+	}
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	if opts.Add {
+		return pusher.AddContext(ctx)
+	}
+
+	return pusher.PushContext(ctx)
+}
+
+func sortedKeys(m map[string]string) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
@@ -0,0 +1,152 @@
+package push
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/UiP9AV6Y/buildinfo"
+	"github.com/UiP9AV6Y/buildinfo/prometheus/collector"
+)
+
+type request struct {
+	method, path, body string
+}
+
+func newTestServer(t *testing.T, delay time.Duration) (*httptest.Server, <-chan request) {
+	t.Helper()
+
+	requests := make(chan request, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- request{method: r.Method, path: r.URL.Path, body: string(body)}
+
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, requests
+}
+
+// grouping parses the grouping key of the given path,
+// whose label order is not deterministic
+func grouping(t *testing.T, path string) map[string]string {
+	t.Helper()
+
+	parts := strings.Split(strings.TrimPrefix(path, "/metrics/"), "/")
+	assert.Equal(t, len(parts)%2, 0, "malformed path %q", path)
+
+	result := make(map[string]string, len(parts)/2)
+	for n := 0; n < len(parts); n += 2 {
+		result[parts[n]] = parts[n+1]
+	}
+
+	return result
+}
+
+func TestPush(t *testing.T) {
+	type testCase struct {
+		have         *buildinfo.BuildInfo
+		opts         Opts
+		wantMethod   string
+		wantGrouping map[string]string
+		wantValue    string
+	}
+
+	withExtras := buildinfo.New()
+	withExtras.Extras = map[string]string{"channel": "nightly"}
+
+	testCases := map[string]testCase{
+		"push": {
+			opts: Opts{
+				Collector: collector.Opts{Namespace: "test"},
+			},
+			wantMethod:   http.MethodPut,
+			wantGrouping: map[string]string{"job": "test"},
+			wantValue:    "0.0.0",
+		},
+		"add": {
+			opts: Opts{
+				Job:       "nightly",
+				Grouping:  map[string]string{"instance": "batch-1", "env": "prod"},
+				Collector: collector.Opts{Namespace: "test"},
+				Add:       true,
+			},
+			wantMethod:   http.MethodPost,
+			wantGrouping: map[string]string{"job": "nightly", "env": "prod", "instance": "batch-1"},
+			wantValue:    "0.0.0",
+		},
+		"extras": {
+			have: withExtras,
+			opts: Opts{
+				Collector: collector.Opts{Namespace: "test"},
+			},
+			wantMethod:   http.MethodPut,
+			wantGrouping: map[string]string{"job": "test"},
+			wantValue:    "nightly",
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			srv, requests := newTestServer(t, 0)
+			have := tc.have
+			if have == nil {
+				have = buildinfo.New()
+			}
+
+			err := Push(context.Background(), srv.URL, have, tc.opts)
+			assert.NilError(t, err)
+
+			got := <-requests
+			assert.Equal(t, got.method, tc.wantMethod)
+			assert.DeepEqual(t, grouping(t, got.path), tc.wantGrouping)
+			assert.Assert(t, strings.Contains(got.body, "test_build_info"))
+			assert.Assert(t, strings.Contains(got.body, tc.wantValue), "got %q", got.body)
+		})
+	}
+}
+
+func TestPushTimeout(t *testing.T) {
+	srv, requests := newTestServer(t, time.Second)
+
+	err := Push(context.Background(), srv.URL, buildinfo.New(), Opts{
+		Collector: collector.Opts{Namespace: "test"},
+		Timeout:   10 * time.Millisecond,
+	})
+	assert.Assert(t, errors.Is(err, context.DeadlineExceeded), "got %v", err)
+
+	<-requests
+}
+
+func TestPushInvalid(t *testing.T) {
+	err := Push(context.Background(), "http://localhost", buildinfo.New(), Opts{
+		Collector: collector.Opts{Namespace: "test", Subsystem: "my-app"},
+	})
+	assert.ErrorIs(t, err, collector.ErrInvalidName)
+}
+
+func TestPushRejected(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "conflict", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	err := Push(context.Background(), srv.URL, buildinfo.New(), Opts{
+		Collector: collector.Opts{Namespace: "test"},
+	})
+	assert.Assert(t, err != nil)
+	assert.Assert(t, strings.Contains(err.Error(), "conflict"), "got %v", err)
+}
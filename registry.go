@@ -0,0 +1,130 @@
+package buildinfo
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	// ErrInvalidComponent is the error used when
+	// registering information without a component name
+	ErrInvalidComponent = errors.New("invalid component name")
+	// ErrDuplicateComponent is the error used when registering
+	// information using a component name which is already in use
+	ErrDuplicateComponent = errors.New("component already registered")
+)
+
+// Registry contains the build information of several independently
+// versioned components of a binary, identified by their name.
+// It is safe for concurrent use.
+type Registry struct {
+	mu    sync.RWMutex
+	infos map[string]*BuildInfo
+}
+
+// DefaultRegistry is the process-wide registry used by Register
+var DefaultRegistry = NewRegistry()
+
+// NewRegistry returns an empty Registry instance
+func NewRegistry() *Registry {
+	return &Registry{
+		infos: map[string]*BuildInfo{},
+	}
+}
+
+// Register adds the given information to the DefaultRegistry
+func Register(component string, info *BuildInfo) error {
+	return DefaultRegistry.Register(component, info)
+}
+
+// MustRegister adds the given information to the DefaultRegistry
+// and panics on error
+func MustRegister(component string, info *BuildInfo) {
+	DefaultRegistry.MustRegister(component, info)
+}
+
+// Register adds a copy of the given information using the provided
+// component name; nil is registered as empty information. An error
+// is returned if the name is empty or already in use.
+func (r *Registry) Register(component string, info *BuildInfo) error {
+	if component == "" {
+		return ErrInvalidComponent
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.infos[component]; ok {
+		return fmt.Errorf("%w: %q", ErrDuplicateComponent, component)
+	}
+
+	if info == nil {
+		info = &BuildInfo{}
+	}
+
+	r.infos[component] = info.Clone()
+
+	return nil
+}
+
+// MustRegister is like Register but panics on error
+func (r *Registry) MustRegister(component string, info *BuildInfo) {
+	if err := r.Register(component, info); err != nil {
+		panic(err)
+	}
+}
+
+// Unregister removes the information of the given component
+// and reports whether it has been registered.
+func (r *Registry) Unregister(component string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.infos[component]
+	delete(r.infos, component)
+
+	return ok
+}
+
+// Get returns a copy of the information of the given component
+func (r *Registry) Get(component string) (*BuildInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	info, ok := r.infos[component]
+	if !ok {
+		return nil, false
+	}
+
+	return info.Clone(), true
+}
+
+// Components returns the names of all registered components in lexical order
+func (r *Registry) Components() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]string, 0, len(r.infos))
+	for k := range r.infos {
+		result = append(result, k)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// All returns copies of the information of all registered components
+func (r *Registry) All() map[string]*BuildInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make(map[string]*BuildInfo, len(r.infos))
+	for k, v := range r.infos {
+		result[k] = v.Clone()
+	}
+
+	return result
+}
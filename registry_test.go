@@ -0,0 +1,67 @@
+package buildinfo
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	reg := NewRegistry()
+	core := &BuildInfo{VersionInfo: &VersionInfo{Version: "1.2.3"}}
+	plugin := &BuildInfo{VersionInfo: &VersionInfo{Version: "0.1.0"}}
+
+	assert.NilError(t, reg.Register("core", core))
+	assert.NilError(t, reg.Register("plugin", plugin))
+
+	err := reg.Register("core", plugin)
+	assert.Assert(t, errors.Is(err, ErrDuplicateComponent))
+
+	err = reg.Register("", plugin)
+	assert.Assert(t, errors.Is(err, ErrInvalidComponent))
+
+	assert.DeepEqual(t, reg.Components(), []string{"core", "plugin"})
+
+	core.Version = "2.0.0"
+	got, ok := reg.Get("core")
+	assert.Assert(t, ok)
+	assert.Equal(t, got.Version, "1.2.3")
+
+	got.Version = "3.0.0"
+	got, _ = reg.Get("core")
+	assert.Equal(t, got.Version, "1.2.3")
+
+	all := reg.All()
+	assert.Equal(t, len(all), 2)
+	assert.Equal(t, all["plugin"].Version, "0.1.0")
+
+	assert.Assert(t, reg.Unregister("plugin"))
+	assert.Assert(t, !reg.Unregister("plugin"))
+
+	_, ok = reg.Get("plugin")
+	assert.Assert(t, !ok)
+	assert.DeepEqual(t, reg.Components(), []string{"core"})
+}
+
+func TestRegistryConcurrent(t *testing.T) {
+	reg := NewRegistry()
+
+	var wg sync.WaitGroup
+	for n := 0; n < 10; n++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+
+			name := fmt.Sprintf("component%d", n)
+			assert.Check(t, reg.Register(name, New()))
+			_ = reg.Components()
+			_, _ = reg.Get(name)
+		}(n)
+	}
+
+	wg.Wait()
+	assert.Equal(t, len(reg.Components()), 10)
+}
@@ -0,0 +1,236 @@
+package buildinfo
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+)
+
+const (
+	// separator between build tags
+	tagConcat = ","
+	// separator between the operating system and the architecture
+	platformConcat = "/"
+)
+
+// debug.BuildSetting keys of interest
+const (
+	settingCompiler   = "-compiler"
+	settingTags       = "-tags"
+	settingTrimPath   = "-trimpath"
+	settingCGOEnabled = "CGO_ENABLED"
+	settingGOOS       = "GOOS"
+	settingGOARCH     = "GOARCH"
+)
+
+// archLevelSettings maps architectures to the debug.BuildSetting key
+// holding the microarchitecture level the binary was compiled for
+var archLevelSettings = map[string]string{
+	"386":      "GO386",
+	"amd64":    "GOAMD64",
+	"arm":      "GOARM",
+	"arm64":    "GOARM64",
+	"mips":     "GOMIPS",
+	"mipsle":   "GOMIPS",
+	"mips64":   "GOMIPS64",
+	"mips64le": "GOMIPS64",
+	"ppc64":    "GOPPC64",
+	"ppc64le":  "GOPPC64",
+	"riscv64":  "GORISCV64",
+	"wasm":     "GOWASM",
+}
+
+// Dependency describes a module the binary was built with
+type Dependency struct {
+	Path    string      `json:"path"`
+	Version string      `json:"version,omitempty"`
+	Sum     string      `json:"sum,omitempty"`
+	Replace *Dependency `json:"replace,omitempty"`
+}
+
+// newDependency converts the given module information.
+func newDependency(m *debug.Module) *Dependency {
+	result := &Dependency{
+		Path:    m.Path,
+		Version: m.Version,
+		Sum:     m.Sum,
+	}
+
+	if m.Replace != nil {
+		result.Replace = newDependency(m.Replace)
+	}
+
+	return result
+}
+
+// String returns the module path and version
+func (d *Dependency) String() string {
+	if d.Replace != nil {
+		return d.Path + "@" + d.Version + " => " + d.Replace.String()
+	}
+
+	return d.Path + "@" + d.Version
+}
+
+// Clone creates an independant copy of itself.
+func (d *Dependency) Clone() *Dependency {
+	d2 := *d
+
+	if d.Replace != nil {
+		d2.Replace = d.Replace.Clone()
+	}
+
+	return &d2
+}
+
+// Equal compares the fields of this instance to the given one
+func (d *Dependency) Equal(o *Dependency) bool {
+	if d == nil || o == nil {
+		return d == nil && o == nil
+	}
+
+	return d.Path == o.Path && d.Version == o.Version && d.Sum == o.Sum && d.Replace.Equal(o.Replace)
+}
+
+// RuntimeInfo contains data about the Golang toolchain, the
+// settings and the dependencies used to compile the running binary
+type RuntimeInfo struct {
+	GoVersion  string        `json:"goversion,omitempty"`
+	GoOS       string        `json:"goos,omitempty"`
+	GoArch     string        `json:"goarch,omitempty"`
+	ArchLevel  string        `json:"goarchlevel,omitempty"`
+	Compiler   string        `json:"compiler,omitempty"`
+	BuildTags  []string      `json:"tags,omitempty"`
+	CGOEnabled bool          `json:"cgo,omitempty"`
+	TrimPath   bool          `json:"trimpath,omitempty"`
+	Path       string        `json:"path,omitempty"`
+	Main       *Dependency   `json:"main,omitempty"`
+	Deps       []*Dependency `json:"deps,omitempty"`
+}
+
+// NewRuntimeInfo returns a RuntimeInfo instance describing the
+// running binary. If the binary has been built without module
+// support, only the information available from the runtime
+// package is provided.
+func NewRuntimeInfo() *RuntimeInfo {
+	if bi, ok := readBuildInfo(); ok {
+		return ParseRuntimeInfo(bi)
+	}
+
+	return &RuntimeInfo{
+		GoVersion: GoVersion,
+		GoOS:      GoOS,
+		GoArch:    GoArch,
+	}
+}
+
+// ParseRuntimeInfo converts the given toolchain build information.
+// Information missing from the input (e.g. the target platform)
+// is populated from the runtime package.
+func ParseRuntimeInfo(bi *debug.BuildInfo) *RuntimeInfo {
+	result := &RuntimeInfo{
+		GoVersion: bi.GoVersion,
+		GoOS:      GoOS,
+		GoArch:    GoArch,
+		Path:      bi.Path,
+		Deps:      make([]*Dependency, 0, len(bi.Deps)),
+	}
+
+	if result.GoVersion == "" {
+		result.GoVersion = GoVersion
+	}
+
+	if bi.Main.Path != "" {
+		result.Main = newDependency(&bi.Main)
+	}
+
+	for _, d := range bi.Deps {
+		result.Deps = append(result.Deps, newDependency(d))
+	}
+
+	settings := make(map[string]string, len(bi.Settings))
+	for _, s := range bi.Settings {
+		settings[s.Key] = s.Value
+	}
+
+	if v, ok := settings[settingGOOS]; ok {
+		result.GoOS = v
+	}
+
+	if v, ok := settings[settingGOARCH]; ok {
+		result.GoArch = v
+	}
+
+	if v, ok := settings[settingTags]; ok && v != "" {
+		result.BuildTags = strings.Split(v, tagConcat)
+	}
+
+	result.ArchLevel = settings[archLevelSettings[result.GoArch]]
+	result.Compiler = settings[settingCompiler]
+	result.CGOEnabled = settings[settingCGOEnabled] == "1"
+	result.TrimPath = settings[settingTrimPath] == "true"
+
+	return result
+}
+
+// String returns toolchain, platform and build settings information.
+func (i *RuntimeInfo) String() string {
+	return fmt.Sprintf("(goversion=%s, platform=%s, tags=%s, cgo=%t, trimpath=%t)",
+		i.GoVersion, i.Platform(), i.Tags(), i.CGOEnabled, i.TrimPath)
+}
+
+// Clone creates an independant copy of itself.
+func (i *RuntimeInfo) Clone() *RuntimeInfo {
+	i2 := *i
+
+	if i.BuildTags != nil {
+		i2.BuildTags = append([]string{}, i.BuildTags...)
+	}
+
+	if i.Main != nil {
+		i2.Main = i.Main.Clone()
+	}
+
+	if i.Deps != nil {
+		i2.Deps = make([]*Dependency, len(i.Deps))
+		for n, d := range i.Deps {
+			i2.Deps[n] = d.Clone()
+		}
+	}
+
+	return &i2
+}
+
+// Equal compares the fields of this instance to the given one
+func (i *RuntimeInfo) Equal(o *RuntimeInfo) bool {
+	if i == nil || o == nil {
+		return i == nil && o == nil
+	}
+
+	if i.GoVersion != o.GoVersion || i.GoOS != o.GoOS || i.GoArch != o.GoArch ||
+		i.ArchLevel != o.ArchLevel || i.Compiler != o.Compiler ||
+		i.CGOEnabled != o.CGOEnabled || i.TrimPath != o.TrimPath ||
+		i.Path != o.Path || i.Tags() != o.Tags() || !i.Main.Equal(o.Main) ||
+		len(i.Deps) != len(o.Deps) {
+		return false
+	}
+
+	for n, d := range i.Deps {
+		if !d.Equal(o.Deps[n]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Platform returns the GoOS and GoArch value
+// concatenated by a slash character
+func (i *RuntimeInfo) Platform() string {
+	return i.GoOS + platformConcat + i.GoArch
+}
+
+// Tags returns the BuildTags concatenated by a comma
+func (i *RuntimeInfo) Tags() string {
+	return strings.Join(i.BuildTags, tagConcat)
+}
@@ -0,0 +1,161 @@
+package buildinfo
+
+import (
+	"runtime/debug"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestParseRuntimeInfo(t *testing.T) {
+	type testCase struct {
+		have *debug.BuildInfo
+		want *RuntimeInfo
+	}
+
+	mockGoRuntime(t)
+
+	testCases := map[string]testCase{
+		"empty": {
+			have: &debug.BuildInfo{},
+			want: &RuntimeInfo{
+				GoVersion: "go1.19.13",
+				GoOS:      "linux",
+				GoArch:    "amd64",
+				Deps:      []*Dependency{},
+			},
+		},
+		"full": {
+			have: &debug.BuildInfo{
+				GoVersion: "go1.21.5",
+				Path:      "example.com/test/cmd/test",
+				Main: debug.Module{
+					Path:    "example.com/test",
+					Version: "v1.2.3",
+					Sum:     "h1:main",
+				},
+				Deps: []*debug.Module{
+					{
+						Path:    "example.com/dep",
+						Version: "v0.1.0",
+						Sum:     "h1:dep",
+					},
+					{
+						Path:    "example.com/fork",
+						Version: "v1.0.0",
+						Replace: &debug.Module{
+							Path:    "../fork",
+							Version: "(devel)",
+						},
+					},
+				},
+				Settings: []debug.BuildSetting{
+					{Key: "-compiler", Value: "gc"},
+					{Key: "-tags", Value: "netgo,osusergo"},
+					{Key: "-trimpath", Value: "true"},
+					{Key: "CGO_ENABLED", Value: "0"},
+					{Key: "GOARCH", Value: "arm"},
+					{Key: "GOARM", Value: "7"},
+					{Key: "GOOS", Value: "freebsd"},
+					{Key: "GOAMD64", Value: "v3"},
+				},
+			},
+			want: &RuntimeInfo{
+				GoVersion: "go1.21.5",
+				GoOS:      "freebsd",
+				GoArch:    "arm",
+				ArchLevel: "7",
+				Compiler:  "gc",
+				BuildTags: []string{"netgo", "osusergo"},
+				TrimPath:  true,
+				Path:      "example.com/test/cmd/test",
+				Main: &Dependency{
+					Path:    "example.com/test",
+					Version: "v1.2.3",
+					Sum:     "h1:main",
+				},
+				Deps: []*Dependency{
+					{
+						Path:    "example.com/dep",
+						Version: "v0.1.0",
+						Sum:     "h1:dep",
+					},
+					{
+						Path:    "example.com/fork",
+						Version: "v1.0.0",
+						Replace: &Dependency{
+							Path:    "../fork",
+							Version: "(devel)",
+						},
+					},
+				},
+			},
+		},
+		"cgo": {
+			have: &debug.BuildInfo{
+				GoVersion: "go1.21.5",
+				Settings: []debug.BuildSetting{
+					{Key: "CGO_ENABLED", Value: "1"},
+					{Key: "GOAMD64", Value: "v1"},
+				},
+			},
+			want: &RuntimeInfo{
+				GoVersion:  "go1.21.5",
+				GoOS:       "linux",
+				GoArch:     "amd64",
+				ArchLevel:  "v1",
+				CGOEnabled: true,
+				Deps:       []*Dependency{},
+			},
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			got := ParseRuntimeInfo(tc.have)
+
+			assert.Assert(t, tc.want.Equal(got), "want=%s; got=%s", tc.want, got)
+		})
+	}
+}
+
+func TestNewRuntimeInfo(t *testing.T) {
+	mockGoRuntime(t)
+	mockReadBuildInfo(t, nil)
+
+	got := NewRuntimeInfo()
+	want := &RuntimeInfo{
+		GoVersion: "go1.19.13",
+		GoOS:      "linux",
+		GoArch:    "amd64",
+	}
+
+	assert.Assert(t, want.Equal(got), "want=%s; got=%s", want, got)
+}
+
+func TestRuntimeInfoClone(t *testing.T) {
+	have := &RuntimeInfo{
+		GoVersion: "go1.21.5",
+		BuildTags: []string{"netgo"},
+		Main: &Dependency{
+			Path: "example.com/test",
+		},
+		Deps: []*Dependency{
+			{
+				Path:    "example.com/dep",
+				Replace: &Dependency{Path: "../dep"},
+			},
+		},
+	}
+	got := have.Clone()
+
+	assert.Assert(t, have.Equal(got))
+
+	got.BuildTags[0] = "osusergo"
+	got.Main.Path = "example.com/other"
+	got.Deps[0].Replace.Path = "../other"
+
+	assert.Equal(t, have.BuildTags[0], "netgo")
+	assert.Equal(t, have.Main.Path, "example.com/test")
+	assert.Equal(t, have.Deps[0].Replace.Path, "../dep")
+}
@@ -0,0 +1,274 @@
+package buildinfo
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// SchemaVersion is the version of the data format written by this
+// library. Input without version information is considered to be
+// written using the initial version.
+const SchemaVersion = 1
+
+const (
+	// JSON key holding the schema version
+	schemaKey = "schema"
+	// schema version of input without version information
+	initialSchemaVersion = 1
+)
+
+var (
+	// ErrUnknownField is the error used when parsing input containing
+	// fields unknown to this library version in Strict mode.
+	ErrUnknownField = errors.New("unknown field")
+	// ErrUnsupportedSchema is the error used when parsing input
+	// written using a newer schema version in Strict mode.
+	ErrUnsupportedSchema = errors.New("unsupported schema version")
+)
+
+// Migration converts the raw fields of a document written using
+// one schema version into the representation of the next version.
+type Migration func(fields map[string]json.RawMessage) error
+
+var (
+	migrationsMu sync.RWMutex
+	migrations   = map[int]Migration{}
+)
+
+// jsonSchema contains the JSON Schema describing the data format
+//
+//go:embed schema.json
+var jsonSchema []byte
+
+// knownFields contains the JSON keys of all BuildInfo fields
+var knownFields = jsonFields(reflect.TypeOf(BuildInfo{}), map[string]struct{}{})
+
+// buildInfo has the same fields as BuildInfo,
+// without the custom JSON (un)marshalling
+type buildInfo BuildInfo
+
+// RegisterMigration makes a Migration from the given schema version
+// to its successor available. The migrations are applied in sequence
+// when parsing input written using an older schema version.
+// If RegisterMigration is called twice for the same version,
+// or if the migration is nil, it panics.
+func RegisterMigration(from int, migration Migration) {
+	migrationsMu.Lock()
+	defer migrationsMu.Unlock()
+
+	if migration == nil {
+		panic("buildinfo: RegisterMigration migration is nil")
+	}
+
+	if _, dup := migrations[from]; dup {
+		panic(fmt.Sprintf("buildinfo: RegisterMigration called twice for schema version %d", from))
+	}
+
+	migrations[from] = migration
+}
+
+// JSONSchema returns the JSON Schema (draft 2020-12) describing
+// the data format of the current SchemaVersion. Like the parser,
+// the schema permits unknown top-level fields for forward compatibility.
+func JSONSchema() []byte {
+	return append([]byte{}, jsonSchema...)
+}
+
+// Schema returns the version of the data format
+// the instance has been parsed from or will be written as.
+func (i *BuildInfo) Schema() int {
+	if i.schema < SchemaVersion {
+		return SchemaVersion
+	}
+
+	return i.schema
+}
+
+// UnknownFields returns the keys of the parsed fields which are
+// unknown to this library version in lexical order. Their values
+// are retained and written back by MarshalJSON.
+func (i *BuildInfo) UnknownFields() []string {
+	result := make([]string, 0, len(i.unknown))
+	for k := range i.unknown {
+		result = append(result, k)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The output contains the schema version as well as
+// any unknown fields retained during parsing.
+func (i *BuildInfo) MarshalJSON() ([]byte, error) {
+	doc := struct {
+		Schema int `json:"schema"`
+		*buildInfo
+	}{
+		Schema:    i.Schema(),
+		buildInfo: (*buildInfo)(i),
+	}
+
+	b, err := json.Marshal(doc)
+	if err != nil || len(i.unknown) == 0 {
+		return b, err
+	}
+
+	buf := bytes.NewBuffer(b[:len(b)-1])
+	for _, k := range i.UnknownFields() {
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.WriteByte(',')
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(i.unknown[k])
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Input written using an older schema version is migrated
+// using the registered migrations. Fields unknown to this
+// library version are retained.
+func (i *BuildInfo) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	if fields == nil {
+		// null
+		return nil
+	}
+
+	schema := initialSchemaVersion
+	if raw, ok := fields[schemaKey]; ok {
+		if err := json.Unmarshal(raw, &schema); err != nil {
+			return fmt.Errorf("Unable to parse %s: %w", schemaKey, err)
+		}
+
+		delete(fields, schemaKey)
+	}
+
+	if err := migrate(fields, schema, SchemaVersion); err != nil {
+		return err
+	}
+
+	known := make(map[string]json.RawMessage, len(fields))
+	unknown := make(map[string]json.RawMessage)
+	for k, v := range fields {
+		if _, ok := knownFields[k]; ok {
+			known[k] = v
+		} else {
+			unknown[k] = v
+		}
+	}
+
+	b, err := json.Marshal(known)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(b, (*buildInfo)(i)); err != nil {
+		return err
+	}
+
+	i.schema = schema
+	i.unknown = nil
+	if len(unknown) > 0 {
+		i.unknown = unknown
+	}
+
+	return nil
+}
+
+// checkStrict verifies the parsed input has been written
+// using a supported schema and only contains known fields.
+func (i *BuildInfo) checkStrict() error {
+	if i.schema > SchemaVersion {
+		return fmt.Errorf("%w %d; latest supported version is %d", ErrUnsupportedSchema, i.schema, SchemaVersion)
+	}
+
+	if len(i.unknown) > 0 {
+		return fmt.Errorf("%w %s", ErrUnknownField, strings.Join(i.UnknownFields(), ", "))
+	}
+
+	return nil
+}
+
+func (i *BuildInfo) cloneUnknown() map[string]json.RawMessage {
+	if len(i.unknown) == 0 {
+		return nil
+	}
+
+	result := make(map[string]json.RawMessage, len(i.unknown))
+	for k, v := range i.unknown {
+		result[k] = append(json.RawMessage{}, v...)
+	}
+
+	return result
+}
+
+// migrate applies the registered migrations to convert
+// the given fields from one schema version into another.
+// Input written using a newer version is left untouched.
+func migrate(fields map[string]json.RawMessage, from, to int) error {
+	migrationsMu.RLock()
+	defer migrationsMu.RUnlock()
+
+	for v := from; v < to; v++ {
+		m, ok := migrations[v]
+		if !ok {
+			continue
+		}
+
+		if err := m(fields); err != nil {
+			return fmt.Errorf("Unable to migrate schema version %d: %w", v, err)
+		}
+	}
+
+	return nil
+}
+
+// jsonFields collects the JSON keys of all exported fields of
+// the given struct type, including the ones of embedded structs.
+func jsonFields(t reflect.Type, fields map[string]struct{}) map[string]struct{} {
+	for n := 0; n < t.NumField(); n++ {
+		f := t.Field(n)
+		if f.Anonymous {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+
+			jsonFields(ft, fields)
+			continue
+		} else if !f.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		} else if name == "" {
+			name = f.Name
+		}
+
+		fields[name] = struct{}{}
+	}
+
+	return fields
+}
@@ -0,0 +1,174 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestParseModeStrict(t *testing.T) {
+	type testCase struct {
+		have      []byte
+		wantError error
+	}
+
+	testCases := map[string]testCase{
+		"empty": {
+			have: []byte("{}"),
+		},
+		"current schema": {
+			have: []byte(`{"schema":1,"version":"1.2.3","extras":{"a":"b"}}`),
+		},
+		"unknown field": {
+			have:      []byte(`{"version":"1.2.3","future":true}`),
+			wantError: ErrUnknownField,
+		},
+		"case mismatch": {
+			have:      []byte(`{"Version":"1.2.3"}`),
+			wantError: ErrUnknownField,
+		},
+		"newer schema": {
+			have:      []byte(`{"schema":999,"version":"1.2.3"}`),
+			wantError: ErrUnsupportedSchema,
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			_, err := ParseMode(tc.have, Strict)
+
+			if tc.wantError != nil {
+				assert.ErrorIs(t, err, tc.wantError)
+			} else {
+				assert.NilError(t, err)
+			}
+
+			_, err = Parse(tc.have)
+			assert.NilError(t, err)
+		})
+	}
+}
+
+func TestParseTypeMismatch(t *testing.T) {
+	_, err := Parse([]byte(`{"version":123}`))
+	assert.Assert(t, err != nil)
+
+	_, err = Parse([]byte(`{"schema":"one"}`))
+	assert.Assert(t, err != nil)
+}
+
+func TestUnknownFieldsRoundTrip(t *testing.T) {
+	have := []byte(`{"schema":2,"version":"1.2.3","future":{"nested":[1,2]},"another":"value"}`)
+
+	info, err := Parse(have)
+	assert.NilError(t, err)
+	assert.Equal(t, info.Version, "1.2.3")
+	assert.Equal(t, info.Schema(), 2)
+	assert.DeepEqual(t, info.UnknownFields(), []string{"another", "future"})
+
+	clone := info.Clone()
+	assert.DeepEqual(t, clone.UnknownFields(), []string{"another", "future"})
+	assert.Assert(t, info.Equal(clone))
+
+	data, err := clone.JSON()
+	assert.NilError(t, err)
+
+	var got map[string]json.RawMessage
+	assert.NilError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, string(got["schema"]), "2")
+	assert.Equal(t, string(got["version"]), `"1.2.3"`)
+	assert.Equal(t, string(got["future"]), `{"nested":[1,2]}`)
+	assert.Equal(t, string(got["another"]), `"value"`)
+}
+
+func TestMarshalJSONSchema(t *testing.T) {
+	data, err := New().JSON()
+	assert.NilError(t, err)
+
+	var got map[string]json.RawMessage
+	assert.NilError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, string(got["schema"]), "1")
+
+	info, err := ParseMode(data, Strict)
+	assert.NilError(t, err)
+	assert.Assert(t, New().VersionInfo.Equal(info.VersionInfo))
+}
+
+func TestMigrate(t *testing.T) {
+	orig := migrations
+	migrations = map[int]Migration{}
+	t.Cleanup(func() {
+		migrations = orig
+	})
+
+	RegisterMigration(1, func(fields map[string]json.RawMessage) error {
+		fields["version"] = fields["release"]
+		delete(fields, "release")
+		return nil
+	})
+	RegisterMigration(2, func(fields map[string]json.RawMessage) error {
+		if _, ok := fields["version"]; !ok {
+			return errors.New("version missing")
+		}
+
+		fields["branch"] = json.RawMessage(`"migrated"`)
+		return nil
+	})
+
+	fields := map[string]json.RawMessage{
+		"release": json.RawMessage(`"1.2.3"`),
+	}
+	assert.NilError(t, migrate(fields, 1, 3))
+	assert.DeepEqual(t, fields, map[string]json.RawMessage{
+		"version": json.RawMessage(`"1.2.3"`),
+		"branch":  json.RawMessage(`"migrated"`),
+	})
+
+	fields = map[string]json.RawMessage{}
+	assert.ErrorContains(t, migrate(fields, 2, 3), "version missing")
+
+	fields = map[string]json.RawMessage{
+		"release": json.RawMessage(`"1.2.3"`),
+	}
+	assert.NilError(t, migrate(fields, 3, 3))
+	assert.Equal(t, len(fields), 1)
+}
+
+func TestRegisterMigrationDuplicate(t *testing.T) {
+	orig := migrations
+	migrations = map[int]Migration{}
+	t.Cleanup(func() {
+		migrations = orig
+	})
+
+	noop := func(map[string]json.RawMessage) error { return nil }
+	RegisterMigration(1, noop)
+
+	defer func() {
+		assert.Assert(t, recover() != nil)
+	}()
+	RegisterMigration(1, noop)
+}
+
+func TestJSONSchema(t *testing.T) {
+	var schema struct {
+		Properties map[string]struct {
+			Maximum int `json:"maximum"`
+		} `json:"properties"`
+	}
+
+	assert.NilError(t, json.Unmarshal(JSONSchema(), &schema))
+	assert.Equal(t, schema.Properties[schemaKey].Maximum, SchemaVersion)
+
+	for k := range knownFields {
+		_, ok := schema.Properties[k]
+		assert.Assert(t, ok, "field %q is not described by the schema", k)
+	}
+
+	for k := range schema.Properties {
+		_, ok := knownFields[k]
+		assert.Assert(t, ok || k == schemaKey, "schema property %q is unknown", k)
+	}
+}
@@ -0,0 +1,262 @@
+package buildinfo
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// optional prefix of version values, e.g. v1.2.3
+	versionPrefix = "v"
+	// separator between the version core and the prerelease information
+	prereleaseConcat = "-"
+	// separator between the version and the build metadata
+	buildConcat = "+"
+	// separator between version core fields and identifiers
+	identifierConcat = "."
+)
+
+// ErrInvalidSemVer is the error used when parsing a value
+// which does not adhere to the Semantic Versioning specification.
+var ErrInvalidSemVer = errors.New("invalid semantic version")
+
+// SemVer is the parsed representation of a version value
+// following the Semantic Versioning 2.0.0 specification.
+type SemVer struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease string
+	Build      string
+}
+
+// TrimVersionPrefix removes the leading "v" from version values
+// such as v1.2.3, which is a common convention for VCS tags.
+// Values without the prefix, or where the prefix is not followed
+// by a digit, are returned as-is.
+func TrimVersionPrefix(version string) string {
+	v := strings.TrimPrefix(version, versionPrefix)
+	if v == version || v == "" || v[0] < '0' || v[0] > '9' {
+		return version
+	}
+
+	return v
+}
+
+// ParseSemVer parses the given value as semantic version.
+// A leading "v" is ignored (see TrimVersionPrefix). The
+// returned error wraps ErrInvalidSemVer if the input
+// does not conform to the specification.
+func ParseSemVer(version string) (*SemVer, error) {
+	v := TrimVersionPrefix(version)
+	result := &SemVer{}
+
+	if core, build, ok := strings.Cut(v, buildConcat); ok {
+		if !validIdentifiers(build, false) {
+			return nil, fmt.Errorf("%w %q: malformed build metadata", ErrInvalidSemVer, version)
+		}
+
+		result.Build = build
+		v = core
+	}
+
+	if core, pre, ok := strings.Cut(v, prereleaseConcat); ok {
+		if !validIdentifiers(pre, true) {
+			return nil, fmt.Errorf("%w %q: malformed prerelease", ErrInvalidSemVer, version)
+		}
+
+		result.Prerelease = pre
+		v = core
+	}
+
+	parts := strings.Split(v, identifierConcat)
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w %q: expected major.minor.patch", ErrInvalidSemVer, version)
+	}
+
+	fields := []*uint64{&result.Major, &result.Minor, &result.Patch}
+	for i, p := range parts {
+		n, err := parseNumeric(p)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %v", ErrInvalidSemVer, version, err)
+		}
+
+		*fields[i] = n
+	}
+
+	return result, nil
+}
+
+// MustParseSemVer calls ParseSemVer and panics on any error
+func MustParseSemVer(version string) *SemVer {
+	result, err := ParseSemVer(version)
+	if err != nil {
+		panic(err)
+	}
+
+	return result
+}
+
+// String returns the canonical representation of the version
+// (without the "v" prefix)
+func (v *SemVer) String() string {
+	result := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+
+	if v.Prerelease != "" {
+		result += prereleaseConcat + v.Prerelease
+	}
+
+	if v.Build != "" {
+		result += buildConcat + v.Build
+	}
+
+	return result
+}
+
+// Clone creates an independant copy of itself.
+func (v *SemVer) Clone() *SemVer {
+	v2 := *v
+
+	return &v2
+}
+
+// Equal compares the fields of this instance to the given one.
+// Unlike Compare, the build metadata is taken into account as well.
+func (v *SemVer) Equal(o *SemVer) bool {
+	if v == nil || o == nil {
+		return v == nil && o == nil
+	}
+
+	return *v == *o
+}
+
+// Compare returns an integer comparing the precedence of two versions
+// according to the Semantic Versioning specification. The result is
+// 0 if v == o, -1 if v < o, and +1 if v > o. Build metadata does not
+// contribute to the precedence. A nil version is considered lower
+// than any other version.
+func (v *SemVer) Compare(o *SemVer) int {
+	if v == nil || o == nil {
+		switch {
+		case v != nil:
+			return 1
+		case o != nil:
+			return -1
+		default:
+			return 0
+		}
+	}
+
+	if c := compareNumeric(v.Major, o.Major); c != 0 {
+		return c
+	}
+
+	if c := compareNumeric(v.Minor, o.Minor); c != 0 {
+		return c
+	}
+
+	if c := compareNumeric(v.Patch, o.Patch); c != 0 {
+		return c
+	}
+
+	return comparePrerelease(v.Prerelease, o.Prerelease)
+}
+
+// IsPrerelease reports whether the version has prerelease information
+func (v *SemVer) IsPrerelease() bool {
+	return v.Prerelease != ""
+}
+
+func parseNumeric(s string) (uint64, error) {
+	if s == "" {
+		return 0, errors.New("empty numeric identifier")
+	} else if len(s) > 1 && s[0] == '0' {
+		return 0, fmt.Errorf("leading zero in numeric identifier %q", s)
+	}
+
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("non-numeric identifier %q", s)
+		}
+	}
+
+	return strconv.ParseUint(s, 10, 64)
+}
+
+func validIdentifiers(s string, strictNumeric bool) bool {
+	for _, id := range strings.Split(s, identifierConcat) {
+		if id == "" {
+			return false
+		}
+
+		numeric := true
+		for _, c := range id {
+			switch {
+			case c >= '0' && c <= '9':
+			case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '-':
+				numeric = false
+			default:
+				return false
+			}
+		}
+
+		if strictNumeric && numeric && len(id) > 1 && id[0] == '0' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func compareNumeric(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func comparePrerelease(a, b string) int {
+	// a version without prerelease has a higher precedence
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	as := strings.Split(a, identifierConcat)
+	bs := strings.Split(b, identifierConcat)
+
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if c := compareIdentifier(as[i], bs[i]); c != 0 {
+			return c
+		}
+	}
+
+	return compareNumeric(uint64(len(as)), uint64(len(bs)))
+}
+
+func compareIdentifier(a, b string) int {
+	an, aErr := strconv.ParseUint(a, 10, 64)
+	bn, bErr := strconv.ParseUint(b, 10, 64)
+
+	switch {
+	case aErr == nil && bErr == nil:
+		return compareNumeric(an, bn)
+	case aErr == nil:
+		// numeric identifiers have lower precedence
+		return -1
+	case bErr == nil:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
@@ -0,0 +1,214 @@
+package buildinfo
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestTrimVersionPrefix(t *testing.T) {
+	testCases := map[string]string{
+		"":        "",
+		"v":       "v",
+		"1.2.3":   "1.2.3",
+		"v1.2.3":  "1.2.3",
+		"v1":      "1",
+		"vv1":     "vv1",
+		"version": "version",
+		"V1.2.3":  "V1.2.3",
+	}
+
+	for have, want := range testCases {
+		t.Run(have, func(t *testing.T) {
+			got := TrimVersionPrefix(have)
+
+			assert.Equal(t, got, want)
+		})
+	}
+}
+
+func TestParseSemVer(t *testing.T) {
+	type testCase struct {
+		have      string
+		wantError bool
+		want      *SemVer
+	}
+
+	testCases := map[string]testCase{
+		"empty": {
+			have:      "",
+			wantError: true,
+		},
+		"default": {
+			have: DefaultVersion,
+			want: &SemVer{},
+		},
+		"core": {
+			have: "1.2.3",
+			want: &SemVer{Major: 1, Minor: 2, Patch: 3},
+		},
+		"prefix": {
+			have: "v1.2.3",
+			want: &SemVer{Major: 1, Minor: 2, Patch: 3},
+		},
+		"prerelease": {
+			have: "1.0.0-alpha.1",
+			want: &SemVer{Major: 1, Prerelease: "alpha.1"},
+		},
+		"prerelease hyphen": {
+			have: "1.0.0-x-y-z.--",
+			want: &SemVer{Major: 1, Prerelease: "x-y-z.--"},
+		},
+		"build": {
+			have: "1.0.0+20130313144700",
+			want: &SemVer{Major: 1, Build: "20130313144700"},
+		},
+		"build leading zero": {
+			have: "1.0.0+001",
+			want: &SemVer{Major: 1, Build: "001"},
+		},
+		"full": {
+			have: "1.0.0-beta+exp.sha.5114f85",
+			want: &SemVer{Major: 1, Prerelease: "beta", Build: "exp.sha.5114f85"},
+		},
+		"partial": {
+			have:      "1.2",
+			wantError: true,
+		},
+		"too many fields": {
+			have:      "1.2.3.4",
+			wantError: true,
+		},
+		"leading zero": {
+			have:      "01.2.3",
+			wantError: true,
+		},
+		"prerelease leading zero": {
+			have:      "1.2.3-01",
+			wantError: true,
+		},
+		"empty prerelease": {
+			have:      "1.2.3-",
+			wantError: true,
+		},
+		"empty identifier": {
+			have:      "1.2.3-rc..1",
+			wantError: true,
+		},
+		"empty build": {
+			have:      "1.2.3+",
+			wantError: true,
+		},
+		"invalid character": {
+			have:      "1.2.3-rc_1",
+			wantError: true,
+		},
+		"non-numeric": {
+			have:      "1.x.3",
+			wantError: true,
+		},
+		"rpm": {
+			have:      "1.2.3~19701230gitd5a3191",
+			wantError: true,
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			got, err := ParseSemVer(tc.have)
+
+			if tc.wantError {
+				assert.ErrorIs(t, err, ErrInvalidSemVer)
+			} else {
+				assert.Assert(t, err)
+				assert.Assert(t, tc.want.Equal(got), "want=%s; got=%s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSemVerString(t *testing.T) {
+	testCases := map[string]string{
+		"0.0.0":              "0.0.0",
+		"v1.2.3":             "1.2.3",
+		"1.2.3-rc.1":         "1.2.3-rc.1",
+		"1.2.3+build.5":      "1.2.3+build.5",
+		"1.2.3-rc.1+build.5": "1.2.3-rc.1+build.5",
+	}
+
+	for have, want := range testCases {
+		t.Run(have, func(t *testing.T) {
+			got := MustParseSemVer(have).String()
+
+			assert.Equal(t, got, want)
+		})
+	}
+}
+
+func TestSemVerCompare(t *testing.T) {
+	type testCase struct {
+		haveLeft, haveRight string
+		want                int
+	}
+
+	testCases := map[string]testCase{
+		"equal": {
+			haveLeft:  "1.2.3",
+			haveRight: "v1.2.3",
+			want:      0,
+		},
+		"major": {
+			haveLeft:  "1.9.9",
+			haveRight: "2.0.0",
+			want:      -1,
+		},
+		"minor": {
+			haveLeft:  "1.10.0",
+			haveRight: "1.9.0",
+			want:      1,
+		},
+		"patch": {
+			haveLeft:  "1.2.3",
+			haveRight: "1.2.4",
+			want:      -1,
+		},
+		"release over prerelease": {
+			haveLeft:  "1.0.0",
+			haveRight: "1.0.0-rc.1",
+			want:      1,
+		},
+		"numeric prerelease": {
+			haveLeft:  "1.0.0-beta.2",
+			haveRight: "1.0.0-beta.11",
+			want:      -1,
+		},
+		"alphanumeric prerelease": {
+			haveLeft:  "1.0.0-alpha.beta",
+			haveRight: "1.0.0-beta",
+			want:      -1,
+		},
+		"numeric lower than alphanumeric": {
+			haveLeft:  "1.0.0-alpha.1",
+			haveRight: "1.0.0-alpha.beta",
+			want:      -1,
+		},
+		"longer prerelease": {
+			haveLeft:  "1.0.0-alpha.1",
+			haveRight: "1.0.0-alpha",
+			want:      1,
+		},
+		"build ignored": {
+			haveLeft:  "1.0.0+a",
+			haveRight: "1.0.0+b",
+			want:      0,
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			got := MustParseSemVer(tc.haveLeft).Compare(MustParseSemVer(tc.haveRight))
+
+			assert.Equal(t, got, tc.want)
+		})
+	}
+}
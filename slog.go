@@ -0,0 +1,76 @@
+//go:build go1.21
+
+package buildinfo
+
+import (
+	"log/slog"
+)
+
+// DefaultLogKey is the attribute key used by NewLogHandler
+// if no key has been provided
+const DefaultLogKey = "build"
+
+// logExtrasKey is the group name of BuildInfo.Extras
+const logExtrasKey = "extras"
+
+// LogValue implements the slog.LogValuer interface.
+// The result is a group containing the Keyvals.
+func (i *VersionInfo) LogValue() slog.Value {
+	return slog.GroupValue(keyvalsAttrs(i.Keyvals())...)
+}
+
+// LogValue implements the slog.LogValuer interface.
+// The result is a group containing the Keyvals.
+func (i *EnvironmentInfo) LogValue() slog.Value {
+	return slog.GroupValue(keyvalsAttrs(i.Keyvals())...)
+}
+
+// LogValue implements the slog.LogValuer interface.
+// The result is a group containing the version- and environment
+// information, with the Extras in a nested "extras" group.
+// The Runtime information is not included.
+func (i *BuildInfo) LogValue() slog.Value {
+	var attrs []slog.Attr
+
+	if i.VersionInfo != nil {
+		attrs = append(attrs, keyvalsAttrs(i.VersionInfo.Keyvals())...)
+	}
+
+	if i.EnvironmentInfo != nil {
+		attrs = append(attrs, keyvalsAttrs(i.EnvironmentInfo.Keyvals())...)
+	}
+
+	if len(i.Extras) > 0 {
+		extras := make([]slog.Attr, 0, len(i.Extras))
+		for _, k := range i.ExtrasKeys() {
+			extras = append(extras, slog.String(k, i.Extras[k]))
+		}
+
+		attrs = append(attrs, slog.Attr{Key: logExtrasKey, Value: slog.GroupValue(extras...)})
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// NewLogHandler returns a handler which adds the given build information
+// as group with the provided key (DefaultLogKey if empty) to every record
+// before passing it on to the wrapped handler.
+func NewLogHandler(h slog.Handler, info *BuildInfo, key string) slog.Handler {
+	if key == "" {
+		key = DefaultLogKey
+	}
+
+	return h.WithAttrs([]slog.Attr{
+		{Key: key, Value: info.Clone().LogValue()},
+	})
+}
+
+// keyvalsAttrs converts the result of a Keyvals call into attributes
+func keyvalsAttrs(kv []interface{}) []slog.Attr {
+	result := make([]slog.Attr, 0, len(kv)/2)
+	for n := 0; n+1 < len(kv); n += 2 {
+		result = append(result, slog.Any(kv[n].(string), kv[n+1]))
+	}
+
+	return result
+}
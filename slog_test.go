@@ -0,0 +1,93 @@
+//go:build go1.21
+
+package buildinfo
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func newLogTestInfo() *BuildInfo {
+	return &BuildInfo{
+		VersionInfo: &VersionInfo{
+			Version:  "1.2.3",
+			Revision: "deadbeef",
+			Branch:   "main",
+			Dirty:    true,
+		},
+		EnvironmentInfo: &EnvironmentInfo{
+			User: "root",
+			Host: "example.com",
+			Date: time.Unix(123456790, 0).UTC(),
+		},
+		Extras: map[string]string{
+			"channel": "stable",
+		},
+	}
+}
+
+func newLogTestHandler(buf *bytes.Buffer) slog.Handler {
+	return slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+}
+
+func TestLogValue(t *testing.T) {
+	info := newLogTestInfo()
+
+	type testCase struct {
+		have slog.LogValuer
+		want string
+	}
+
+	testCases := map[string]testCase{
+		"version": {
+			have: info.VersionInfo,
+			want: `level=INFO msg=test info.version=1.2.3 info.revision=deadbeef info.branch=main info.dirty=true` + "\n",
+		},
+		"environment": {
+			have: info.EnvironmentInfo,
+			want: `level=INFO msg=test info.user=root info.host=example.com info.date=1973-11-29T21:33:10.000Z` + "\n",
+		},
+		"build": {
+			have: info,
+			want: `level=INFO msg=test info.version=1.2.3 info.revision=deadbeef info.branch=main info.dirty=true` +
+				` info.user=root info.host=example.com info.date=1973-11-29T21:33:10.000Z info.extras.channel=stable` + "\n",
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			var buf bytes.Buffer
+			slog.New(newLogTestHandler(&buf)).Info("test", "info", tc.have)
+
+			assert.Equal(t, buf.String(), tc.want)
+		})
+	}
+}
+
+func TestNewLogHandler(t *testing.T) {
+	info := newLogTestInfo()
+
+	var buf bytes.Buffer
+	logger := slog.New(NewLogHandler(newLogTestHandler(&buf), info, ""))
+	info.Version = "2.0.0"
+
+	logger.Info("first")
+	logger.Info("second", "key", "value")
+
+	want := `level=INFO msg=first build.version=1.2.3 build.revision=deadbeef build.branch=main build.dirty=true` +
+		` build.user=root build.host=example.com build.date=1973-11-29T21:33:10.000Z build.extras.channel=stable` + "\n" +
+		`level=INFO msg=second build.version=1.2.3 build.revision=deadbeef build.branch=main build.dirty=true` +
+		` build.user=root build.host=example.com build.date=1973-11-29T21:33:10.000Z build.extras.channel=stable key=value` + "\n"
+	assert.Equal(t, buf.String(), want)
+}
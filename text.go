@@ -0,0 +1,361 @@
+package buildinfo
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The text encoding is a single line of space separated key=value pairs:
+//
+//	line  = pair *( 1*WSP pair )
+//	pair  = token "=" token
+//	token = bare / quoted
+//	bare  = 1*( any printable character except WSP, "=" and DQUOTE )
+//	quoted = a Go string literal (see strconv.Quote)
+//
+// Keys are the same as the ones used by the JSON encoding. Entries of
+// BuildInfo.Extras use their key with an "extra." prefix. Fields with
+// zero values are omitted, timestamps use the RFC 3339 format.
+
+const (
+	// separator between key and value
+	textAssign = '='
+	// separator between pairs
+	textConcat = ' '
+	// prefix for BuildInfo.Extras keys
+	textExtraPrefix = "extra."
+)
+
+// text encoding keys
+const (
+	textVersion         = "version"
+	textRevision        = "revision"
+	textBranch          = "branch"
+	textDirty           = "dirty"
+	textCommitDate      = "commit_date"
+	textCommitAuthor    = "commit_author"
+	textCommitsSinceTag = "commits_since_tag"
+	textUser            = "user"
+	textHost            = "host"
+	textDate            = "date"
+)
+
+// ErrInvalidText is the error used when decoding malformed text input
+var ErrInvalidText = errors.New("invalid text encoding")
+
+// textPair is a single decoded key=value entry
+type textPair struct {
+	key, value string
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// The result is a single line of key=value pairs.
+func (i *VersionInfo) MarshalText() ([]byte, error) {
+	return i.appendText(nil), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// All fields are reset before the input is applied.
+func (i *VersionInfo) UnmarshalText(text []byte) error {
+	pairs, err := parseText(text)
+	if err != nil {
+		return err
+	}
+
+	*i = VersionInfo{}
+	for _, p := range pairs {
+		if ok, err := i.setText(p.key, p.value); err != nil {
+			return err
+		} else if !ok {
+			return fmt.Errorf("%w: unknown key %q", ErrInvalidText, p.key)
+		}
+	}
+
+	return nil
+}
+
+func (i *VersionInfo) appendText(b []byte) []byte {
+	b = appendTextString(b, textVersion, i.Version)
+	b = appendTextString(b, textRevision, i.Revision)
+	b = appendTextString(b, textBranch, i.Branch)
+	if i.Dirty {
+		b = appendTextPair(b, textDirty, strconv.FormatBool(i.Dirty))
+	}
+	if i.CommitDate != nil {
+		b = appendTextPair(b, textCommitDate, i.CommitDate.Format(time.RFC3339Nano))
+	}
+	b = appendTextString(b, textCommitAuthor, i.CommitAuthor)
+	if i.CommitsSinceTag > 0 {
+		b = appendTextPair(b, textCommitsSinceTag, strconv.FormatUint(uint64(i.CommitsSinceTag), 10))
+	}
+
+	return b
+}
+
+func (i *VersionInfo) setText(key, value string) (ok bool, err error) {
+	ok = true
+	switch key {
+	case textVersion:
+		i.Version = value
+	case textRevision:
+		i.Revision = value
+	case textBranch:
+		i.Branch = value
+	case textDirty:
+		i.Dirty, err = strconv.ParseBool(value)
+	case textCommitDate:
+		var d time.Time
+		if d, err = time.Parse(time.RFC3339Nano, value); err == nil {
+			i.CommitDate = &d
+		}
+	case textCommitAuthor:
+		i.CommitAuthor = value
+	case textCommitsSinceTag:
+		var n uint64
+		n, err = strconv.ParseUint(value, 10, 0)
+		i.CommitsSinceTag = uint(n)
+	default:
+		ok = false
+	}
+
+	if err != nil {
+		err = fmt.Errorf("%w: %s: %v", ErrInvalidText, key, err)
+	}
+
+	return
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// The result is a single line of key=value pairs.
+func (i *EnvironmentInfo) MarshalText() ([]byte, error) {
+	return i.appendText(nil), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// All fields are reset before the input is applied.
+func (i *EnvironmentInfo) UnmarshalText(text []byte) error {
+	pairs, err := parseText(text)
+	if err != nil {
+		return err
+	}
+
+	*i = EnvironmentInfo{}
+	for _, p := range pairs {
+		if ok, err := i.setText(p.key, p.value); err != nil {
+			return err
+		} else if !ok {
+			return fmt.Errorf("%w: unknown key %q", ErrInvalidText, p.key)
+		}
+	}
+
+	return nil
+}
+
+func (i *EnvironmentInfo) appendText(b []byte) []byte {
+	b = appendTextString(b, textUser, i.User)
+	b = appendTextString(b, textHost, i.Host)
+	if !i.Date.IsZero() {
+		b = appendTextPair(b, textDate, i.Date.Format(time.RFC3339Nano))
+	}
+
+	return b
+}
+
+func (i *EnvironmentInfo) setText(key, value string) (ok bool, err error) {
+	ok = true
+	switch key {
+	case textUser:
+		i.User = value
+	case textHost:
+		i.Host = value
+	case textDate:
+		i.Date, err = time.Parse(time.RFC3339Nano, value)
+	default:
+		ok = false
+	}
+
+	if err != nil {
+		err = fmt.Errorf("%w: %s: %v", ErrInvalidText, key, err)
+	}
+
+	return
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// The result is a single line of key=value pairs containing the
+// version- and environment information, followed by the Extras.
+// The Runtime information is not part of the encoding.
+func (i *BuildInfo) MarshalText() ([]byte, error) {
+	var b []byte
+
+	if i.VersionInfo != nil {
+		b = i.VersionInfo.appendText(b)
+	}
+
+	if i.EnvironmentInfo != nil {
+		b = i.EnvironmentInfo.appendText(b)
+	}
+
+	for _, k := range i.ExtrasKeys() {
+		b = appendTextPair(b, textExtraPrefix+k, i.Extras[k])
+	}
+
+	return b, nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// All fields are reset before the input is applied.
+func (i *BuildInfo) UnmarshalText(text []byte) error {
+	pairs, err := parseText(text)
+	if err != nil {
+		return err
+	}
+
+	*i = BuildInfo{
+		VersionInfo:     &VersionInfo{},
+		EnvironmentInfo: &EnvironmentInfo{},
+	}
+
+	for _, p := range pairs {
+		if strings.HasPrefix(p.key, textExtraPrefix) {
+			if i.Extras == nil {
+				i.Extras = map[string]string{}
+			}
+
+			i.Extras[strings.TrimPrefix(p.key, textExtraPrefix)] = p.value
+			continue
+		}
+
+		if ok, err := i.VersionInfo.setText(p.key, p.value); err != nil {
+			return err
+		} else if ok {
+			continue
+		}
+
+		if ok, err := i.EnvironmentInfo.setText(p.key, p.value); err != nil {
+			return err
+		} else if !ok {
+			return fmt.Errorf("%w: unknown key %q", ErrInvalidText, p.key)
+		}
+	}
+
+	return nil
+}
+
+// Set implements the flag.Value interface by
+// decoding the given value using UnmarshalText.
+// String returns the human-readable form instead of
+// the text encoding; use MarshalText to obtain a value
+// suitable for Set.
+func (i *BuildInfo) Set(value string) error {
+	return i.UnmarshalText([]byte(value))
+}
+
+// appendTextString appends the given key and
+// value, unless the value is an empty string.
+func appendTextString(b []byte, key, value string) []byte {
+	if value == "" {
+		return b
+	}
+
+	return appendTextPair(b, key, value)
+}
+
+// appendTextPair appends the given key and value
+func appendTextPair(b []byte, key, value string) []byte {
+	if len(b) > 0 {
+		b = append(b, textConcat)
+	}
+
+	b = appendTextToken(b, key)
+	b = append(b, textAssign)
+	b = appendTextToken(b, value)
+
+	return b
+}
+
+func appendTextToken(b []byte, token string) []byte {
+	if token == "" || strings.IndexFunc(token, needsQuote) >= 0 {
+		return strconv.AppendQuote(b, token)
+	}
+
+	return append(b, token...)
+}
+
+func needsQuote(r rune) bool {
+	return r == textAssign || r == '"' || r == utf8.RuneError || unicode.IsSpace(r) || !unicode.IsPrint(r)
+}
+
+// parseText splits the given text into its key=value pairs.
+func parseText(text []byte) ([]textPair, error) {
+	var result []textPair
+
+	s := string(text)
+	seen := map[string]struct{}{}
+	for {
+		s = strings.TrimLeftFunc(s, unicode.IsSpace)
+		if s == "" {
+			return result, nil
+		}
+
+		key, rest, err := parseTextToken(s)
+		if err != nil {
+			return nil, err
+		}
+
+		if rest == "" || rest[0] != textAssign {
+			return nil, fmt.Errorf("%w: missing %q after key %q", ErrInvalidText, textAssign, key)
+		}
+
+		value, rest, err := parseTextToken(rest[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		if rest != "" && !unicode.IsSpace(rune(rest[0])) {
+			return nil, fmt.Errorf("%w: missing separator after key %q", ErrInvalidText, key)
+		}
+
+		if _, dup := seen[key]; dup {
+			return nil, fmt.Errorf("%w: duplicate key %q", ErrInvalidText, key)
+		}
+
+		seen[key] = struct{}{}
+		result = append(result, textPair{key: key, value: value})
+		s = rest
+	}
+}
+
+// parseTextToken reads a bare or quoted token from the start
+// of the given string and returns it along with the remainder.
+func parseTextToken(s string) (token, rest string, err error) {
+	if s != "" && s[0] == '"' {
+		quoted, err := strconv.QuotedPrefix(s)
+		if err != nil {
+			return "", "", fmt.Errorf("%w: malformed quoted string: %v", ErrInvalidText, err)
+		}
+
+		token, err = strconv.Unquote(quoted)
+		if err != nil {
+			return "", "", fmt.Errorf("%w: malformed quoted string: %v", ErrInvalidText, err)
+		}
+
+		return token, s[len(quoted):], nil
+	}
+
+	n := strings.IndexFunc(s, needsQuote)
+	if n < 0 {
+		n = len(s)
+	}
+
+	if n == 0 {
+		return "", "", fmt.Errorf("%w: unexpected character at %q", ErrInvalidText, s)
+	}
+
+	return s[:n], s[n:], nil
+}
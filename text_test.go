@@ -0,0 +1,215 @@
+package buildinfo
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestBuildInfoMarshalText(t *testing.T) {
+	type testCase struct {
+		have *BuildInfo
+		want string
+	}
+
+	commitDate := time.Unix(1700000000, 0).UTC()
+	testCases := map[string]testCase{
+		"empty": {
+			have: &BuildInfo{},
+			want: "",
+		},
+		"default": {
+			have: &BuildInfo{
+				VersionInfo: NewVersionInfo(),
+				EnvironmentInfo: &EnvironmentInfo{
+					User: DefaultUser,
+					Host: DefaultHost,
+				},
+			},
+			want: "version=0.0.0 revision=HEAD branch=trunk user=unknown host=localhost",
+		},
+		"full": {
+			have: &BuildInfo{
+				VersionInfo: &VersionInfo{
+					Version:         "1.2.3-rc.1+build.5",
+					Revision:        "deadbeefcafe",
+					Branch:          "feature/text",
+					Dirty:           true,
+					CommitDate:      &commitDate,
+					CommitAuthor:    "Gordon Bleux",
+					CommitsSinceTag: 12,
+				},
+				EnvironmentInfo: &EnvironmentInfo{
+					User: "root",
+					Host: "localhost",
+					Date: time.Unix(0, 123456789).UTC(),
+				},
+				Extras: map[string]string{
+					"channel": "stable",
+					"note":    `a "quoted" value=1`,
+					"empty":   "",
+					"multi":   "line\nbreak",
+				},
+			},
+			want: `version=1.2.3-rc.1+build.5 revision=deadbeefcafe branch=feature/text dirty=true ` +
+				`commit_date=2023-11-14T22:13:20Z commit_author="Gordon Bleux" commits_since_tag=12 ` +
+				`user=root host=localhost date=1970-01-01T00:00:00.123456789Z ` +
+				`extra.channel=stable extra.empty="" extra.multi="line\nbreak" extra.note="a \"quoted\" value=1"`,
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			got, err := tc.have.MarshalText()
+			assert.NilError(t, err)
+			assert.Equal(t, string(got), tc.want)
+
+			parsed := &BuildInfo{}
+			assert.NilError(t, parsed.UnmarshalText(got))
+
+			if tc.have.VersionInfo == nil {
+				tc.have.VersionInfo = &VersionInfo{}
+			}
+			if tc.have.EnvironmentInfo == nil {
+				tc.have.EnvironmentInfo = &EnvironmentInfo{}
+			}
+			assert.Assert(t, tc.have.Equal(parsed), "want=%s; got=%s", tc.have, parsed)
+		})
+	}
+}
+
+func TestBuildInfoUnmarshalText(t *testing.T) {
+	type testCase struct {
+		have      string
+		wantError bool
+		want      *BuildInfo
+	}
+
+	testCases := map[string]testCase{
+		"whitespace": {
+			have: "\tversion=1.0.0   user=root \n",
+			want: &BuildInfo{
+				VersionInfo:     &VersionInfo{Version: "1.0.0"},
+				EnvironmentInfo: &EnvironmentInfo{User: "root"},
+			},
+		},
+		"quoted key": {
+			have: `"extra.my key"="ä"`,
+			want: &BuildInfo{
+				VersionInfo:     &VersionInfo{},
+				EnvironmentInfo: &EnvironmentInfo{},
+				Extras:          map[string]string{"my key": "ä"},
+			},
+		},
+		"unknown key": {
+			have:      "version=1.0.0 future=true",
+			wantError: true,
+		},
+		"duplicate key": {
+			have:      "version=1.0.0 version=2.0.0",
+			wantError: true,
+		},
+		"missing value": {
+			have:      "version",
+			wantError: true,
+		},
+		"empty value": {
+			have:      "version=",
+			wantError: true,
+		},
+		"missing separator": {
+			have:      `version="1.0.0"branch=main`,
+			wantError: true,
+		},
+		"unterminated quote": {
+			have:      `version="1.0.0`,
+			wantError: true,
+		},
+		"invalid bool": {
+			have:      "dirty=maybe",
+			wantError: true,
+		},
+		"invalid date": {
+			have:      "date=yesterday",
+			wantError: true,
+		},
+		"invalid number": {
+			have:      "commits_since_tag=-1",
+			wantError: true,
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			got := New()
+			err := got.UnmarshalText([]byte(tc.have))
+
+			if tc.wantError {
+				assert.Assert(t, errors.Is(err, ErrInvalidText), "got=%v", err)
+			} else {
+				assert.NilError(t, err)
+				assert.Assert(t, tc.want.Equal(got), "want=%s; got=%s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestVersionInfoText(t *testing.T) {
+	have := &VersionInfo{
+		Version:  "1.2.3",
+		Revision: "deadbeef",
+		Dirty:    true,
+	}
+
+	text, err := have.MarshalText()
+	assert.NilError(t, err)
+	assert.Equal(t, string(text), "version=1.2.3 revision=deadbeef dirty=true")
+
+	got := NewVersionInfo()
+	assert.NilError(t, got.UnmarshalText(text))
+	assert.Assert(t, have.Equal(got), "want=%s; got=%s", have, got)
+
+	assert.Assert(t, got.UnmarshalText([]byte("user=root")) != nil)
+}
+
+func TestEnvironmentInfoText(t *testing.T) {
+	have := &EnvironmentInfo{
+		User: "build bot",
+		Date: time.Date(2024, 3, 16, 12, 18, 16, 0, time.UTC),
+	}
+
+	text, err := have.MarshalText()
+	assert.NilError(t, err)
+	assert.Equal(t, string(text), `user="build bot" date=2024-03-16T12:18:16Z`)
+
+	got := NewEnvironmentInfo()
+	assert.NilError(t, got.UnmarshalText(text))
+	assert.Assert(t, have.Equal(got), "want=%s; got=%s", have, got)
+
+	assert.Assert(t, got.UnmarshalText([]byte("version=1.0.0")) != nil)
+}
+
+func TestBuildInfoFlag(t *testing.T) {
+	info := New()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(info, "info", "build information")
+
+	err := fs.Parse([]string{"--info", `version=1.2.3 branch=main extra.channel=stable`})
+	assert.NilError(t, err)
+	assert.Equal(t, info.Version, "1.2.3")
+	assert.Equal(t, info.Branch, "main")
+	assert.Equal(t, info.Revision, "")
+	assert.Equal(t, info.Extras["channel"], "stable")
+
+	err = fs.Parse([]string{"--info", `version=1.2.3 bogus`})
+	assert.Assert(t, err != nil)
+
+	fs.PrintDefaults()
+	assert.Equal(t, (*BuildInfo)(nil).String(), "")
+	assert.Assert(t, (&BuildInfo{}).String() != "")
+}
@@ -0,0 +1,319 @@
+package app
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+
+	"github.com/UiP9AV6Y/buildinfo"
+	"github.com/UiP9AV6Y/buildinfo/tools/parser"
+	"github.com/UiP9AV6Y/buildinfo/tools/parser/file"
+	"github.com/UiP9AV6Y/buildinfo/tools/parser/fossil"
+	"github.com/UiP9AV6Y/buildinfo/tools/parser/git"
+	"github.com/UiP9AV6Y/buildinfo/tools/parser/hg"
+	"github.com/UiP9AV6Y/buildinfo/tools/parser/mock"
+	"github.com/UiP9AV6Y/buildinfo/tools/parser/svn"
+	"github.com/UiP9AV6Y/buildinfo/tools/renderer/golang"
+	"github.com/UiP9AV6Y/buildinfo/tools/renderer/json"
+)
+
+// Application is a logic router implementation
+type Application struct {
+	Filename, ProjectDir                  string
+	Format, Namespace                     string
+	VersionParser                         string
+	GitExe, HgExe, SvnExe, FossilExe      string
+	MockVersion, MockRevision, MockBranch string
+	Extras, EnvExtras                     Extras
+	DiffFormat                            string
+
+	name string
+}
+
+// New create a new Application instance
+func New(name string) *Application {
+	result := &Application{
+		Extras:    Extras{},
+		EnvExtras: Extras{},
+		name:      name,
+	}
+
+	return result
+}
+
+// String returns the application name
+func (a *Application) String() string {
+	return a.name
+}
+
+// Stdin checks if the instructions request output to STDOUT
+func (a *Application) Stdout() bool {
+	return a.Filename == "" || a.Filename == "-"
+}
+
+// Stdin checks if the instructions request input from STDIN
+func (a *Application) Stdin() bool {
+	return a.ProjectDir == "" || a.ProjectDir == "-"
+}
+
+// Run is a logic switch operating on the configured Format to produce
+func (a *Application) Run(logger log.Logger) error {
+	switch a.Format {
+	case "", "version", "buildinfo", "metadata":
+		return a.GenerateBuildInfo(logger)
+	case "golang-embed":
+		return a.GenerateGolangEmbed(logger)
+	default:
+		return fmt.Errorf("Invalid generator instruction %q", a.Format)
+	}
+}
+
+// GenerateBuildInfo parses the buildinfo data and renders them using JSON.
+func (a *Application) GenerateBuildInfo(logger log.Logger) error {
+	level.Debug(logger).Log("msg", "Parsing build information", "input", a.ProjectDir)
+
+	v, err := a.versionInfo(logger)
+	if err != nil {
+		return err
+	}
+
+	e, err := a.environmentInfo(logger)
+	if err != nil {
+		return err
+	}
+
+	i := buildinfo.NewBuildInfo(v, e)
+	if x := a.extras(); len(x) > 0 {
+		i.Extras = x
+	}
+
+	r := json.NewMinified()
+
+	return a.write(func(o string, w io.Writer) error {
+		b, err := r.RenderBuildInfo(i)
+		if err != nil {
+			return err
+		}
+
+		level.Info(logger).Log("msg", "Writing BuildInfo data", "output", o)
+
+		_, err = w.Write(b)
+		return err
+	})
+}
+
+// GenerateGolangEmbed renders Golang code with buildinfo embed instructions.
+func (a *Application) GenerateGolangEmbed(logger log.Logger) error {
+	level.Debug(logger).Log("msg", "Rendering Golang embed code", "name", a.name, "namespace", a.namespace())
+
+	e := golang.NewArgs(a.namespace(), a.name, a.embedArgs()...)
+
+	return a.write(func(o string, w io.Writer) error {
+		b, err := e.RenderBuildInfo(nil)
+		if err != nil {
+			return err
+		}
+
+		level.Info(logger).Log("msg", "Writing Golang embed code", "output", o)
+
+		_, err = w.Write(b)
+		return err
+	})
+}
+
+func (a *Application) versionInfo(logger log.Logger) (*buildinfo.VersionInfo, error) {
+	var vp parser.VersionParser
+	var err error
+
+	switch a.VersionParser {
+	case "":
+		vp, err = parser.ParseVersionParser(a.ProjectDir)
+	case "file":
+		vp, err = file.TryParse(a.ProjectDir)
+	case "git":
+		if a.GitExe != "" {
+			vp, err = git.TryParse(a.GitExe, a.ProjectDir)
+		} else {
+			vp, err = git.TrySystemParse(a.ProjectDir)
+		}
+	case "hg":
+		if a.HgExe != "" {
+			vp, err = hg.TryParse(a.HgExe, a.ProjectDir)
+		} else {
+			vp, err = hg.TrySystemParse(a.ProjectDir)
+		}
+	case "svn":
+		if a.SvnExe != "" {
+			vp, err = svn.TryParse(a.SvnExe, a.ProjectDir)
+		} else {
+			vp, err = svn.TrySystemParse(a.ProjectDir)
+		}
+	case "fossil":
+		if a.FossilExe != "" {
+			vp, err = fossil.TryParse(a.FossilExe, a.ProjectDir)
+		} else {
+			vp, err = fossil.TrySystemParse(a.ProjectDir)
+		}
+	case "mock":
+		vp, err = mock.TryParse(a.MockVersion, a.MockRevision, a.MockBranch)
+	default:
+		err = fmt.Errorf("Invalid version parser %q", a.VersionParser)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	level.Info(logger).Log("msg", "Parsing version information", "parser", &lazyReflect{v: vp})
+
+	return vp.ParseVersionInfo()
+}
+
+func (a *Application) environmentInfo(logger log.Logger) (*buildinfo.EnvironmentInfo, error) {
+	ep, err := parser.ParseEnvironmentParser()
+	if err != nil {
+		return nil, err
+	}
+
+	level.Info(logger).Log("msg", "Parsing environment information", "parser", &lazyReflect{v: ep})
+
+	return ep.ParseEnvironmentInfo()
+}
+
+func (a *Application) write(consumer func(string, io.Writer) error) error {
+	var o string
+	var w io.Writer
+	if a.Stdout() {
+		o = "STDOUT"
+		w = os.Stdout
+	} else {
+		p, f, err := mkdirFile(a.Filename)
+		if err != nil {
+			return err
+		}
+
+		b := bufio.NewWriter(f)
+
+		o = p
+		w = b
+		defer func() {
+			b.Flush()
+			f.Close()
+		}()
+	}
+
+	return consumer(o, w)
+}
+
+func (a *Application) embedArgs() []string {
+	result := golang.DefaultArgs(a.input(), a.name)
+
+	if a.VersionParser != "" {
+		result = append(result, "--parser.version", a.VersionParser)
+	}
+
+	switch a.VersionParser {
+	case "git":
+		if a.GitExe != "" {
+			result = append(result, "--git.exe", a.GitExe)
+		}
+	case "hg":
+		if a.HgExe != "" {
+			result = append(result, "--hg.exe", a.HgExe)
+		}
+	case "svn":
+		if a.SvnExe != "" {
+			result = append(result, "--svn.exe", a.SvnExe)
+		}
+	case "fossil":
+		if a.FossilExe != "" {
+			result = append(result, "--fossil.exe", a.FossilExe)
+		}
+	case "mock":
+		if a.MockVersion != "" {
+			result = append(result, "--mock.version", a.MockVersion)
+		}
+		if a.MockRevision != "" {
+			result = append(result, "--mock.revision", a.MockRevision)
+		}
+		if a.MockBranch != "" {
+			result = append(result, "--mock.branch", a.MockBranch)
+		}
+	}
+
+	// extras from the environment are specific to the current
+	// build host and therefore not persisted
+	for _, e := range a.Extras.Args() {
+		result = append(result, "--extra", strconv.Quote(e))
+	}
+
+	return result
+}
+
+// extras merges the extras from the environment with the ones
+// provided via flags, with the latter taking precedence
+func (a *Application) extras() Extras {
+	result := make(Extras, len(a.EnvExtras)+len(a.Extras))
+	for k, v := range a.EnvExtras {
+		result[k] = v
+	}
+	for k, v := range a.Extras {
+		result[k] = v
+	}
+
+	return result
+}
+
+func (a *Application) input() string {
+	if !a.Stdin() {
+		return a.ProjectDir
+	}
+
+	return "."
+}
+
+func (a *Application) namespace() string {
+	if a.Namespace != "" {
+		return a.Namespace
+	} else if a.Stdout() {
+		d, err := os.Getwd()
+		if err != nil {
+			return a.name
+		}
+
+		return filepath.Base(d)
+	}
+
+	f, err := filepath.Abs(a.Filename)
+	if err != nil {
+		return a.name
+	}
+
+	return filepath.Base(filepath.Dir(f))
+}
+
+func mkdirFile(i string) (string, *os.File, error) {
+	f, err := filepath.Abs(i)
+	if err != nil {
+		return "", nil, err
+	}
+
+	d := filepath.Dir(f)
+	err = os.MkdirAll(d, 0755)
+	if err != nil {
+		return "", nil, err
+	}
+
+	w, err := os.Create(f)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return f, w, nil
+}
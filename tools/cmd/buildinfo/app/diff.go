@@ -0,0 +1,73 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+
+	"github.com/UiP9AV6Y/buildinfo"
+)
+
+// Diff compares the build information of the given files and writes
+// the differences to w using the configured DiffFormat.
+func (a *Application) Diff(logger log.Logger, files []string, w io.Writer) error {
+	if len(files) != 2 {
+		return errors.New("Exactly two files are required for comparison")
+	}
+
+	var render func(*buildinfo.Diff) ([]byte, error)
+	switch a.DiffFormat {
+	case "", "text":
+		render = func(d *buildinfo.Diff) ([]byte, error) {
+			if d.Empty() {
+				return nil, nil
+			}
+
+			return []byte(d.String() + "\n"), nil
+		}
+	case "json":
+		render = func(d *buildinfo.Diff) ([]byte, error) {
+			b, err := json.Marshal(d)
+			if err != nil {
+				return nil, err
+			}
+
+			return append(b, '\n'), nil
+		}
+	default:
+		return fmt.Errorf("Invalid diff format %q", a.DiffFormat)
+	}
+
+	infos := make([]*buildinfo.BuildInfo, len(files))
+	for n, f := range files {
+		level.Debug(logger).Log("msg", "Parsing build information", "input", f)
+
+		data, err := readFile(f)
+		if err != nil {
+			return fmt.Errorf("Unable to read %s: %w", f, err)
+		}
+
+		// the date is left at its zero value if absent, to
+		// avoid reporting the time of parsing as difference
+		i := buildinfo.New()
+		i.Date = time.Time{}
+		if err := json.Unmarshal(data, i); err != nil {
+			return fmt.Errorf("Unable to parse %s: %w", f, err)
+		}
+
+		infos[n] = i
+	}
+
+	b, err := render(infos[0].Diff(infos[1]))
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(b)
+	return err
+}
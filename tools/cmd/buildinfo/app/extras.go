@@ -0,0 +1,72 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// separator between key and value of an extra entry
+	extraConcat = "="
+	// environment variable prefix for extra entries
+	extraEnvPrefix = "BUILDINFO_EXTRA_"
+)
+
+// Extras is a flag.Value implementation collecting
+// user-defined key-value pairs
+type Extras map[string]string
+
+// ParseExtrasEnv extracts extra entries from the given environment
+// (as returned by os.Environ). Only variables starting with
+// BUILDINFO_EXTRA_ are considered, with the remainder of the
+// variable name lowercased serving as key.
+func ParseExtrasEnv(environ []string) Extras {
+	result := Extras{}
+
+	for _, e := range environ {
+		k, v, ok := strings.Cut(e, extraConcat)
+		if !ok || !strings.HasPrefix(k, extraEnvPrefix) || len(k) == len(extraEnvPrefix) {
+			continue
+		}
+
+		result[strings.ToLower(k[len(extraEnvPrefix):])] = v
+	}
+
+	return result
+}
+
+// String implements the flag.Value interface
+func (e Extras) String() string {
+	return strings.Join(e.Args(), ", ")
+}
+
+// Set implements the flag.Value interface
+func (e Extras) Set(s string) error {
+	k, v, ok := strings.Cut(s, extraConcat)
+	if !ok || k == "" {
+		return fmt.Errorf("Invalid extra entry %q; expected key=value", s)
+	}
+
+	e[k] = v
+
+	return nil
+}
+
+// Args returns the entries in their key=value form,
+// sorted lexically by their key
+func (e Extras) Args() []string {
+	keys := make([]string, 0, len(e))
+	for k := range e {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	result := make([]string, len(keys))
+	for n, k := range keys {
+		result[n] = k + extraConcat + e[k]
+	}
+
+	return result
+}
@@ -0,0 +1,138 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestParseExtrasEnv(t *testing.T) {
+	type testCase struct {
+		have []string
+		want Extras
+	}
+
+	testCases := map[string]testCase{
+		"empty": {
+			have: nil,
+			want: Extras{},
+		},
+		"prefixed": {
+			have: []string{"BUILDINFO_EXTRA_CHANNEL=stable", "HOME=/root", "BUILDINFO_VERSION=1.2.3"},
+			want: Extras{"channel": "stable"},
+		},
+		"value with separator": {
+			have: []string{"BUILDINFO_EXTRA_FLAGS=a=b"},
+			want: Extras{"flags": "a=b"},
+		},
+		"empty value": {
+			have: []string{"BUILDINFO_EXTRA_CHANNEL="},
+			want: Extras{"channel": ""},
+		},
+		"empty key": {
+			have: []string{"BUILDINFO_EXTRA_=stable"},
+			want: Extras{},
+		},
+		"malformed": {
+			have: []string{"BUILDINFO_EXTRA_CHANNEL"},
+			want: Extras{},
+		},
+		"duplicate key": {
+			have: []string{"BUILDINFO_EXTRA_CHANNEL=stable", "BUILDINFO_EXTRA_channel=beta"},
+			want: Extras{"channel": "beta"},
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			got := ParseExtrasEnv(tc.have)
+			assert.DeepEqual(t, got, tc.want)
+		})
+	}
+}
+
+func TestExtrasSet(t *testing.T) {
+	type testCase struct {
+		have      []string
+		wantError bool
+		want      Extras
+	}
+
+	testCases := map[string]testCase{
+		"single": {
+			have: []string{"channel=stable"},
+			want: Extras{"channel": "stable"},
+		},
+		"value with separator": {
+			have: []string{"flags=a=b"},
+			want: Extras{"flags": "a=b"},
+		},
+		"empty value": {
+			have: []string{"channel="},
+			want: Extras{"channel": ""},
+		},
+		"duplicate key": {
+			have: []string{"channel=stable", "channel=beta"},
+			want: Extras{"channel": "beta"},
+		},
+		"empty key": {
+			have:      []string{"=stable"},
+			wantError: true,
+		},
+		"malformed": {
+			have:      []string{"channel"},
+			wantError: true,
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			got := Extras{}
+
+			var err error
+			for _, s := range tc.have {
+				if err = got.Set(s); err != nil {
+					break
+				}
+			}
+
+			if tc.wantError {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.NilError(t, err)
+				assert.DeepEqual(t, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestExtrasArgs(t *testing.T) {
+	type testCase struct {
+		have Extras
+		want []string
+	}
+
+	testCases := map[string]testCase{
+		"empty": {
+			have: Extras{},
+			want: []string{},
+		},
+		"sorted by key": {
+			have: Extras{"ci.pipeline": "42", "ci": "true", "channel": "stable"},
+			want: []string{"channel=stable", "ci=true", "ci.pipeline=42"},
+		},
+		"value with separator": {
+			have: Extras{"flags": "a=b"},
+			want: []string{"flags=a=b"},
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			got := tc.have.Args()
+			assert.DeepEqual(t, got, tc.want)
+			assert.Equal(t, tc.have.String(), strings.Join(tc.want, ", "))
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+
+	"github.com/UiP9AV6Y/buildinfo/tools/validator"
+)
+
+// ErrInvalid is returned by Validate if any of the files violate the schema
+var ErrInvalid = errors.New("Validation failed")
+
+// Validate checks the given files against the buildinfo JSON Schema.
+// Each violation is written to w in the form file:line:column: message.
+// A filename of "-" reads the data from STDIN.
+func (a *Application) Validate(logger log.Logger, files []string, w io.Writer) error {
+	if len(files) == 0 {
+		return errors.New("No files to validate")
+	}
+
+	v, err := validator.New()
+	if err != nil {
+		return fmt.Errorf("Unable to compile JSON schema: %w", err)
+	}
+
+	invalid := 0
+	for _, f := range files {
+		level.Debug(logger).Log("msg", "Validating build information", "input", f)
+
+		data, err := readFile(f)
+		if err != nil {
+			return fmt.Errorf("Unable to read %s: %w", f, err)
+		}
+
+		violations, err := v.Validate(data)
+		if err != nil {
+			return fmt.Errorf("Unable to validate %s: %w", f, err)
+		}
+
+		for _, v := range violations {
+			fmt.Fprintf(w, "%s:%s\n", f, v)
+		}
+
+		if len(violations) > 0 {
+			invalid++
+		}
+	}
+
+	if invalid > 0 {
+		return fmt.Errorf("%w for %d of %d files", ErrInvalid, invalid, len(files))
+	}
+
+	return nil
+}
+
+func readFile(f string) ([]byte, error) {
+	if f == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+
+	return os.ReadFile(f)
+}
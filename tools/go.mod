@@ -5,6 +5,7 @@ go 1.19
 require (
 	github.com/UiP9AV6Y/buildinfo v0.0.0-20240316121816-2a0a49f5d3c2
 	github.com/go-kit/log v0.2.1
+	github.com/santhosh-tekuri/jsonschema/v5 v5.3.1
 	gotest.tools/v3 v3.5.1
 )
 
@@ -12,3 +13,5 @@ require (
 	github.com/go-logfmt/logfmt v0.5.1 // indirect
 	github.com/google/go-cmp v0.5.9 // indirect
 )
+
+replace github.com/UiP9AV6Y/buildinfo => ../
@@ -0,0 +1,202 @@
+package fossil
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/UiP9AV6Y/buildinfo"
+	"github.com/UiP9AV6Y/buildinfo/tools/util"
+)
+
+const (
+	systemFossil = "fossil"
+	// timeline entry format; fields are separated by fieldConcat
+	timelineFmt = "%H|%d|%a|%b|%t"
+	fieldConcat = "|"
+	fieldCount  = 5
+	// separator used by fossil to list multiple tags
+	tagConcat = ", "
+	// layout of the timeline check-in date
+	dateLayout = "2006-01-02 15:04:05"
+)
+
+var (
+	// Error when trying to parse a directory that is not part of a Fossil checkout
+	ErrNoRepository = fs.ErrNotExist
+	// CheckoutFiles contains the names of the database files
+	// fossil creates in the root directory of a checkout
+	CheckoutFiles = []string{".fslckout", "_FOSSIL_"}
+)
+
+// parser.VersionParser implementation using Fossil as data backend
+type Fossil struct {
+	cmd  string
+	root string
+}
+
+// TrySystemParse calls TryParse using the Fossil command found in the PATH
+func TrySystemParse(path string) (*Fossil, error) {
+	return TryParse(systemFossil, path)
+}
+
+// TryParse attempts to parse the given directory as Fossil checkout
+// using the provided command. The checkout root is detected by searching
+// the directory and its parents for one of the CheckoutFiles.
+// If the given path does not seem to be part of a Fossil checkout,
+// ErrNoRepository is returned. All other errors are a result of file
+// access problems.
+func TryParse(cmd, path string) (*Fossil, error) {
+	realCmd, err := exec.LookPath(cmd)
+	if err != nil {
+		// might be a fossil checkout, but we have no fossil commandline client
+		return nil, ErrNoRepository
+	}
+
+	root, err := findRoot(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(realCmd, root), nil
+}
+
+// NewSystem creates a new parser.Parser instance using the provided
+// directory as project root. the fossil executable is invoked as-is,
+// relying on its presence in one of the PATH directories.
+func NewSystem(root string) *Fossil {
+	return New(systemFossil, root)
+}
+
+// New creates a new parser.Parser instance using the provided
+// directory as project root. the fossil executable is invoked using
+// the provided path.
+func New(cmd, root string) *Fossil {
+	result := &Fossil{
+		cmd:  cmd,
+		root: root,
+	}
+
+	return result
+}
+
+// String implements the fmt.Stringer interface
+func (f *Fossil) String() string {
+	return fmt.Sprintf("(cmd=%s, root=%s)", f.cmd, f.root)
+}
+
+// Equal compares the fields of this instance to the given one
+func (f *Fossil) Equal(o *Fossil) bool {
+	if o == nil {
+		return f == nil
+	}
+
+	return f.cmd == o.cmd && f.root == o.root
+}
+
+// ParseVersionInfo implements the parser.VersionParser interface.
+// The version is derived from the most recent tag found in the
+// ancestry of the current check-in, ignoring branch tags.
+func (f *Fossil) ParseVersionInfo() (*buildinfo.VersionInfo, error) {
+	result := buildinfo.NewVersionInfo()
+
+	branch, err := f.fossil("branch", "current")
+	if err != nil {
+		return nil, fmt.Errorf("Unable to determine fossil branch: %w", err)
+	} else if branch != "" {
+		result.Branch = branch
+	}
+
+	changes, err := f.fossil("changes")
+	if err != nil {
+		return nil, fmt.Errorf("Unable to determine fossil checkout state: %w", err)
+	}
+	result.Dirty = changes != ""
+
+	timeline, err := f.fossil("timeline", "ancestors", "current", "--type", "ci",
+		"--limit", "0", "--width", "0", "--format", timelineFmt)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to determine fossil check-in details: %w", err)
+	}
+
+	var distance uint
+	for _, line := range strings.Split(timeline, "\n") {
+		fields := strings.SplitN(line, fieldConcat, fieldCount)
+		if len(fields) != fieldCount {
+			// timeline annotations such as "+++ no more data +++"
+			continue
+		}
+
+		if result.Revision == buildinfo.DefaultRevision {
+			date, err := time.ParseInLocation(dateLayout, fields[1], time.UTC)
+			if err != nil {
+				return nil, fmt.Errorf("Unable to parse fossil check-in date: %w", err)
+			}
+
+			result.Revision = fields[0]
+			result.CommitDate = &date
+			result.CommitAuthor = fields[2]
+		}
+
+		if tag := releaseTag(fields[4], fields[3]); tag != "" {
+			result.Version = buildinfo.TrimVersionPrefix(tag)
+			result.CommitsSinceTag = distance
+			break
+		}
+
+		distance++
+	}
+
+	if result.Revision == buildinfo.DefaultRevision {
+		return nil, fmt.Errorf("Unable to determine fossil check-in")
+	}
+
+	return result, nil
+}
+
+func (f *Fossil) fossil(arg ...string) (string, error) {
+	argv := append(arg, "--chdir", f.root)
+
+	return util.RunCmd(f.cmd, argv)
+}
+
+// releaseTag returns the first tag which does not
+// denote the branch of the check-in
+func releaseTag(tags, branch string) string {
+	for _, tag := range strings.Split(tags, tagConcat) {
+		if tag != "" && tag != branch {
+			return tag
+		}
+	}
+
+	return ""
+}
+
+func findRoot(path string) (string, error) {
+	dir, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		for _, name := range CheckoutFiles {
+			_, err := os.Stat(filepath.Join(dir, name))
+			if err == nil {
+				return dir, nil
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				return "", err
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ErrNoRepository
+		}
+		dir = parent
+	}
+}
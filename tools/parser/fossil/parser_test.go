@@ -0,0 +1,159 @@
+package fossil
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/UiP9AV6Y/buildinfo"
+)
+
+func mockFossilBin() (string, string, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", "", err
+	}
+	mockPath := wd + "/testdata"
+
+	os.Setenv("PATH", mockPath)
+
+	return mockPath + "/fossil-mock.sh", mockPath, nil
+}
+
+func TestTryParse(t *testing.T) {
+	type testCase struct {
+		haveCmd, havePath string
+		wantError         bool
+		want              *Fossil
+	}
+
+	fossilBin, testdata, err := mockFossilBin()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := map[string]testCase{
+		"not in PATH": {
+			haveCmd:   "fossil-notexists",
+			havePath:  testdata + "/checkout",
+			wantError: true,
+		},
+		"no fossil checkout": {
+			haveCmd:   "fossil-mock.sh",
+			havePath:  testdata,
+			wantError: true,
+		},
+		"relative bin": {
+			haveCmd:  "fossil-mock.sh",
+			havePath: testdata + "/checkout",
+			want:     New(fossilBin, testdata+"/checkout"),
+		},
+		"absolute bin": {
+			haveCmd:  fossilBin,
+			havePath: testdata + "/checkout",
+			want:     New(fossilBin, testdata+"/checkout"),
+		},
+		"subdirectory": {
+			haveCmd:  "fossil-mock.sh",
+			havePath: testdata + "/checkout/src/internal",
+			want:     New(fossilBin, testdata+"/checkout"),
+		},
+		"legacy checkout": {
+			haveCmd:  "fossil-mock.sh",
+			havePath: testdata + "/legacy",
+			want:     New(fossilBin, testdata+"/legacy"),
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			got, err := TryParse(tc.haveCmd, tc.havePath)
+
+			if tc.wantError {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+				assert.Assert(t, tc.want.Equal(got), "want=%s; got=%s", tc.want, got)
+			}
+		})
+	}
+
+	_, err = TryParse("fossil-mock.sh", testdata)
+	assert.ErrorIs(t, err, ErrNoRepository)
+}
+
+func TestParseVersionInfo(t *testing.T) {
+	type testCase struct {
+		have      *Fossil
+		wantError bool
+		want      *buildinfo.VersionInfo
+	}
+
+	fossilBin, _, err := mockFossilBin()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitDate := time.Unix(1700000000, 0)
+	testCases := map[string]testCase{
+		"all parsed": {
+			have: New(fossilBin, "/mock/PARSE_ALL"),
+			want: &buildinfo.VersionInfo{
+				Version:      "1.23.456",
+				Revision:     "deadbeefcafe",
+				Branch:       "test_mock",
+				CommitDate:   &commitDate,
+				CommitAuthor: "gbleux",
+			},
+		},
+		"dirty": {
+			have: New(fossilBin, "/mock/PARSE_DIRTY"),
+			want: &buildinfo.VersionInfo{
+				Version:         "1.23.456",
+				Revision:        "deadbeefcafe",
+				Branch:          "test_mock",
+				Dirty:           true,
+				CommitDate:      &commitDate,
+				CommitAuthor:    "gbleux",
+				CommitsSinceTag: 2,
+			},
+		},
+		"no tag": {
+			have: New(fossilBin, "/mock/PARSE_NO_TAG"),
+			want: &buildinfo.VersionInfo{
+				Version:      "0.0.0",
+				Revision:     "deadbeefcafe",
+				Branch:       "test_mock",
+				CommitDate:   &commitDate,
+				CommitAuthor: "gbleux",
+			},
+		},
+		"no rev": {
+			have:      New(fossilBin, "/mock/PARSE_REV_FAIL"),
+			wantError: true,
+		},
+		"no branch": {
+			have:      New(fossilBin, "/mock/PARSE_BRANCH_FAIL"),
+			wantError: true,
+		},
+		"invalid date": {
+			have:      New(fossilBin, "/mock/PARSE_DATE_FAIL"),
+			wantError: true,
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			got, err := tc.have.ParseVersionInfo()
+
+			if tc.wantError {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+				assert.Assert(t, tc.want.Equal(got), "want=%s; got=%s", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+#!/bin/sh -eu
+
+mock_timeline() {
+  case "$1" in
+    "NO_TAG")
+      echo "deadbeefcafe|2023-11-14 22:13:20|gbleux|test_mock|test_mock"
+      echo "cafebabe0000|2023-11-13 10:00:00|gbleux|trunk|trunk"
+      ;;
+    "DIRTY")
+      echo "deadbeefcafe|2023-11-14 22:13:20|gbleux|test_mock|test_mock"
+      echo "cafebabe0000|2023-11-13 10:00:00|gbleux|test_mock|"
+      echo "cafebabe0001|2023-11-12 10:00:00|gbleux|trunk|trunk, v1.23.456, release"
+      ;;
+    "DATE_FAIL")
+      echo "deadbeefcafe|yesterday|gbleux|test_mock|test_mock"
+      ;;
+    *)
+      echo "deadbeefcafe|2023-11-14 22:13:20|gbleux|test_mock|test_mock, v1.23.456"
+      ;;
+  esac
+  echo "+++ no more data (2) +++"
+}
+
+MOCK_STRATEGY=""
+for arg in "$@"; do
+  MOCK_STRATEGY="${arg##*/PARSE_}"
+done
+
+case "$*" in
+  "branch current --chdir "*)
+    if test "$MOCK_STRATEGY" = "BRANCH_FAIL"; then
+      echo "not within an open check-out" >&2
+      exit 1
+    fi
+    echo "test_mock"
+    ;;
+  "changes --chdir "*)
+    if test "$MOCK_STRATEGY" = "DIRTY"; then
+      echo "EDITED     README.md"
+      echo "ADDED      version.go"
+    fi
+    ;;
+  "timeline ancestors current --type ci --limit 0 --width 0 --format %H|%d|%a|%b|%t --chdir "*)
+    if test "$MOCK_STRATEGY" = "REV_FAIL"; then
+      echo "+++ no more data (0) +++"
+    else
+      mock_timeline "$MOCK_STRATEGY"
+    fi
+    ;;
+  *)
+    echo "Invalid mock usage: $*" >&2
+    exit 1
+    ;;
+esac
+
+:
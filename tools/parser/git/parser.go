@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"io/fs"
 	"os/exec"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/UiP9AV6Y/buildinfo"
 	"github.com/UiP9AV6Y/buildinfo/tools/util"
@@ -108,11 +110,46 @@ func (g *Git) ParseVersionInfo() (*buildinfo.VersionInfo, error) {
 		result.Revision = revision
 	}
 
+	// untracked files (e.g. generated ones) leave the worktree clean
+	status, err := g.git("status", "--porcelain", "--untracked-files=no")
+	if err != nil {
+		return nil, fmt.Errorf("Unable to determine git worktree state: %w", err)
+	}
+	result.Dirty = status != ""
+
+	commit, err := g.git("log", "-1", "--format=%ct %an")
+	if err != nil {
+		return nil, fmt.Errorf("Unable to determine git HEAD commit details: %w", err)
+	} else if commit != "" {
+		timestamp, author, _ := strings.Cut(commit, " ")
+		unix, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to parse git HEAD commit date: %w", err)
+		}
+
+		date := time.Unix(unix, 0).UTC()
+		result.CommitDate = &date
+		result.CommitAuthor = author
+	}
+
 	// ignore error in case the project has no tags
-	version, _ := g.git("describe", "--tags", "--abbrev=0")
-	if version != "" {
-		result.Version = strings.TrimPrefix(version, "v")
+	tag, _ := g.git("describe", "--tags", "--abbrev=0")
+	if tag == "" {
+		return result, nil
+	}
+
+	result.Version = buildinfo.TrimVersionPrefix(tag)
+
+	distance, err := g.git("rev-list", "--count", tag+"..HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("Unable to determine git commits since tag %q: %w", tag, err)
+	}
+
+	count, err := strconv.ParseUint(distance, 10, 0)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to parse git commits since tag %q: %w", tag, err)
 	}
+	result.CommitsSinceTag = uint(count)
 
 	return result, nil
 }
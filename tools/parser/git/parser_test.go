@@ -3,6 +3,7 @@ package git
 import (
 	"os"
 	"testing"
+	"time"
 
 	"gotest.tools/v3/assert"
 
@@ -86,21 +87,48 @@ func TestParseVersionInfo(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	commitDate := time.Unix(1700000000, 0)
 	testCases := map[string]testCase{
 		"all parsed": {
 			have: New(gitBin, "/mock/PARSE_ALL"),
 			want: &buildinfo.VersionInfo{
-				Version:  "1.23.456",
-				Revision: "deadbeefcafe",
-				Branch:   "test_mock",
+				Version:      "1.23.456",
+				Revision:     "deadbeefcafe",
+				Branch:       "test_mock",
+				CommitDate:   &commitDate,
+				CommitAuthor: "Gordon Bleux",
+			},
+		},
+		"dirty": {
+			have: New(gitBin, "/mock/PARSE_DIRTY"),
+			want: &buildinfo.VersionInfo{
+				Version:         "1.23.456",
+				Revision:        "deadbeefcafe",
+				Branch:          "test_mock",
+				Dirty:           true,
+				CommitDate:      &commitDate,
+				CommitAuthor:    "Gordon Bleux",
+				CommitsSinceTag: 12,
+			},
+		},
+		"untracked": {
+			have: New(gitBin, "/mock/PARSE_UNTRACKED"),
+			want: &buildinfo.VersionInfo{
+				Version:      "1.23.456",
+				Revision:     "deadbeefcafe",
+				Branch:       "test_mock",
+				CommitDate:   &commitDate,
+				CommitAuthor: "Gordon Bleux",
 			},
 		},
 		"no tag": {
 			have: New(gitBin, "/mock/PARSE_TAG_FAIL"),
 			want: &buildinfo.VersionInfo{
-				Version:  "0.0.0",
-				Revision: "deadbeefcafe",
-				Branch:   "test_mock",
+				Version:      "0.0.0",
+				Revision:     "deadbeefcafe",
+				Branch:       "test_mock",
+				CommitDate:   &commitDate,
+				CommitAuthor: "Gordon Bleux",
 			},
 		},
 		"no rev": {
@@ -27,6 +27,22 @@ mock_parse() {
         echo "deadbeefcafe"
       fi
       ;;
+    "status --porcelain --untracked-files=no")
+      if test "$1" = "DIRTY"; then
+        echo " M README.md"
+      fi
+      ;;
+    "status --porcelain")
+      if test "$1" = "DIRTY"; then
+        echo " M README.md"
+      fi
+      if test "$1" = "DIRTY" || test "$1" = "UNTRACKED"; then
+        echo "?? version.go"
+      fi
+      ;;
+    "log -1 --format=%ct %an")
+      echo "1700000000 Gordon Bleux"
+      ;;
     "describe --tags --abbrev=0")
       if test "$1" = "TAG_FAIL"; then
         echo "fatal: No names found, cannot describe anything." >&2
@@ -35,6 +51,13 @@ mock_parse() {
         echo "v1.23.456"
       fi
       ;;
+    "rev-list --count v1.23.456..HEAD")
+      if test "$1" = "DIRTY"; then
+        echo "12"
+      else
+        echo "0"
+      fi
+      ;;
     *)
       echo "Invalid mock usage"; exit 1 ;;
   esac
@@ -46,7 +69,7 @@ if test $# -lt 2; then
   exit 1
 fi
 
-if test "$1" -ne "-C"; then
+if test "$1" != "-C"; then
   echo "Missing working directory argument" >&2
   exit 1
 fi
@@ -61,6 +84,8 @@ case "$MOCK_STRATEGY" in
   /mock/PARSE_TAG_FAIL) mock_parse "TAG_FAIL" "$*" ;;
   /mock/PARSE_REV_FAIL) mock_parse "REV_FAIL" "$*" ;;
   /mock/PARSE_BRANCH_FAIL) mock_parse "BRANCH_FAIL" "$*" ;;
+  /mock/PARSE_DIRTY) mock_parse "DIRTY" "$*" ;;
+  /mock/PARSE_UNTRACKED) mock_parse "UNTRACKED" "$*" ;;
   *)
     echo "Invalid mock strategy $MOCK_STRATEGY" >&2
     exit 1
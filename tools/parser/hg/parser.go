@@ -0,0 +1,169 @@
+package hg
+
+import (
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/UiP9AV6Y/buildinfo"
+	"github.com/UiP9AV6Y/buildinfo/tools/util"
+)
+
+const (
+	systemHg = "hg"
+	// hg error message when root yields no result
+	errParse = "no repository found"
+	// hg template value in the absence of tags
+	noTag = "null"
+	// hg template separator for multiple tags on the same revision
+	tagConcat = ":"
+)
+
+// Error when trying to parse a directory that is not a Mercurial repository
+var ErrNoRepository = fs.ErrNotExist
+
+// parser.VersionParser implementation using Mercurial as data backend
+type Hg struct {
+	cmd  string
+	root string
+}
+
+// TrySystemParse calls TryParse using the Mercurial command found in the PATH
+func TrySystemParse(path string) (*Hg, error) {
+	return TryParse(systemHg, path)
+}
+
+// TryParse attempts to parse the given directory as Mercurial repository
+// using the provided command.
+// If the given path does not seem to be a Mercurial repository,
+// ErrNoRepository is returned. All other errors are a result of file
+// access problems or data corruption issues.
+func TryParse(cmd, path string) (*Hg, error) {
+	realCmd, err := exec.LookPath(cmd)
+	if err != nil {
+		// might be a hg repo, but we have no hg commandline client
+		return nil, ErrNoRepository
+	}
+
+	argv := []string{"--cwd", path, "root"}
+	o, err := util.RunCmd(realCmd, argv)
+	if err != nil {
+		if strings.Contains(err.Error(), errParse) {
+			// ignore the error type, as long as the error output
+			// contains hints about the failure cause
+			return nil, ErrNoRepository
+		}
+
+		return nil, err
+	}
+
+	return New(realCmd, o), nil
+}
+
+// NewSystem creates a new parser.Parser instance using the provided
+// directory as project root. the hg executable is invoked as-is,
+// relying on its presence in one of the PATH directories.
+func NewSystem(root string) *Hg {
+	return New(systemHg, root)
+}
+
+// New creates a new parser.Parser instance using the provided
+// directory as project root. the hg executable is invoked using
+// the provided path.
+func New(cmd, root string) *Hg {
+	result := &Hg{
+		cmd:  cmd,
+		root: root,
+	}
+
+	return result
+}
+
+// String implements the fmt.Stringer interface
+func (h *Hg) String() string {
+	return fmt.Sprintf("(cmd=%s, root=%s)", h.cmd, h.root)
+}
+
+// Equal compares the fields of this instance to the given one
+func (h *Hg) Equal(o *Hg) bool {
+	if o == nil {
+		return h == nil
+	}
+
+	return h.cmd == o.cmd && h.root == o.root
+}
+
+// ParseVersionInfo implements the parser.VersionParser interface
+func (h *Hg) ParseVersionInfo() (*buildinfo.VersionInfo, error) {
+	result := buildinfo.NewVersionInfo()
+
+	branch, err := h.hg("branch")
+	if err != nil {
+		return nil, fmt.Errorf("Unable to determine current hg branch: %w", err)
+	} else if branch != "" {
+		result.Branch = branch
+	}
+
+	revision, err := h.hg("log", "-r", ".", "--template", "{node}")
+	if err != nil {
+		return nil, fmt.Errorf("Unable to determine hg working directory revision: %w", err)
+	} else if revision != "" {
+		result.Revision = revision
+	}
+
+	// untracked files (e.g. generated ones) leave the working directory clean
+	status, err := h.hg("status", "--modified", "--added", "--removed", "--deleted")
+	if err != nil {
+		return nil, fmt.Errorf("Unable to determine hg working directory state: %w", err)
+	}
+	result.Dirty = status != ""
+
+	commit, err := h.hg("log", "-r", ".", "--template", "{word(0, date|hgdate)} {author|person}")
+	if err != nil {
+		return nil, fmt.Errorf("Unable to determine hg revision details: %w", err)
+	} else if commit != "" {
+		timestamp, author, _ := strings.Cut(commit, " ")
+		unix, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to parse hg revision date: %w", err)
+		}
+
+		date := time.Unix(unix, 0).UTC()
+		result.CommitDate = &date
+		result.CommitAuthor = author
+	}
+
+	tags, err := h.hg("log", "-r", ".", "--template", "{latesttag}")
+	if err != nil {
+		return nil, fmt.Errorf("Unable to determine latest hg tag: %w", err)
+	}
+
+	tag, _, _ := strings.Cut(tags, tagConcat)
+	if tag == "" || tag == noTag {
+		return result, nil
+	}
+
+	result.Version = buildinfo.TrimVersionPrefix(tag)
+
+	distance, err := h.hg("log", "-r", ".", "--template", "{latesttagdistance}")
+	if err != nil {
+		return nil, fmt.Errorf("Unable to determine hg commits since tag %q: %w", tag, err)
+	}
+
+	count, err := strconv.ParseUint(distance, 10, 0)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to parse hg commits since tag %q: %w", tag, err)
+	}
+	result.CommitsSinceTag = uint(count)
+
+	return result, nil
+}
+
+func (h *Hg) hg(arg ...string) (string, error) {
+	argv := append([]string{"--cwd", h.root}, arg...)
+
+	return util.RunCmd(h.cmd, argv)
+}
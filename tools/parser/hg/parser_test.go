@@ -0,0 +1,169 @@
+package hg
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/UiP9AV6Y/buildinfo"
+)
+
+func mockHgBin() (string, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	mockPath := wd + "/testdata"
+
+	os.Setenv("PATH", mockPath)
+
+	return mockPath + "/hg-mock.sh", nil
+}
+
+func TestTryParse(t *testing.T) {
+	type testCase struct {
+		haveCmd, havePath string
+		wantError         bool
+		want              *Hg
+	}
+
+	hgBin, err := mockHgBin()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := map[string]testCase{
+		"not in PATH": {
+			haveCmd:   "hg-notexists",
+			wantError: true,
+		},
+		"no hg repo": {
+			haveCmd:   "hg-mock.sh",
+			havePath:  "/mock/NOT_HG_REPO",
+			wantError: true,
+		},
+		"hg error": {
+			haveCmd:   "hg-mock.sh",
+			havePath:  "/mock/FAIL",
+			wantError: true,
+		},
+		"relative bin": {
+			haveCmd:  "hg-mock.sh",
+			havePath: "/mock/ROOT",
+			want:     New(hgBin, "/mock/src"),
+		},
+		"absolute bin": {
+			haveCmd:  hgBin,
+			havePath: "/mock/ROOT",
+			want:     New(hgBin, "/mock/src"),
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			got, err := TryParse(tc.haveCmd, tc.havePath)
+
+			if tc.wantError {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+				assert.Assert(t, tc.want.Equal(got), "want=%s; got=%s", tc.want, got)
+			}
+		})
+	}
+
+	_, err = TryParse("hg-mock.sh", "/mock/NOT_HG_REPO")
+	assert.ErrorIs(t, err, ErrNoRepository)
+}
+
+func TestParseVersionInfo(t *testing.T) {
+	type testCase struct {
+		have      *Hg
+		wantError bool
+		want      *buildinfo.VersionInfo
+	}
+
+	hgBin, err := mockHgBin()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitDate := time.Unix(1700000000, 0)
+	testCases := map[string]testCase{
+		"all parsed": {
+			have: New(hgBin, "/mock/PARSE_ALL"),
+			want: &buildinfo.VersionInfo{
+				Version:      "1.23.456",
+				Revision:     "deadbeefcafe",
+				Branch:       "test_mock",
+				CommitDate:   &commitDate,
+				CommitAuthor: "Gordon Bleux",
+			},
+		},
+		"dirty": {
+			have: New(hgBin, "/mock/PARSE_DIRTY"),
+			want: &buildinfo.VersionInfo{
+				Version:         "1.23.456",
+				Revision:        "deadbeefcafe",
+				Branch:          "test_mock",
+				Dirty:           true,
+				CommitDate:      &commitDate,
+				CommitAuthor:    "Gordon Bleux",
+				CommitsSinceTag: 12,
+			},
+		},
+		"untracked": {
+			have: New(hgBin, "/mock/PARSE_UNTRACKED"),
+			want: &buildinfo.VersionInfo{
+				Version:      "1.23.456",
+				Revision:     "deadbeefcafe",
+				Branch:       "test_mock",
+				CommitDate:   &commitDate,
+				CommitAuthor: "Gordon Bleux",
+			},
+		},
+		"no tag": {
+			have: New(hgBin, "/mock/PARSE_NO_TAG"),
+			want: &buildinfo.VersionInfo{
+				Version:      "0.0.0",
+				Revision:     "deadbeefcafe",
+				Branch:       "test_mock",
+				CommitDate:   &commitDate,
+				CommitAuthor: "Gordon Bleux",
+			},
+		},
+		"multiple tags": {
+			have: New(hgBin, "/mock/PARSE_MULTI_TAG"),
+			want: &buildinfo.VersionInfo{
+				Version:      "1.23.456",
+				Revision:     "deadbeefcafe",
+				Branch:       "test_mock",
+				CommitDate:   &commitDate,
+				CommitAuthor: "Gordon Bleux",
+			},
+		},
+		"no rev": {
+			have:      New(hgBin, "/mock/PARSE_REV_FAIL"),
+			wantError: true,
+		},
+		"no branch": {
+			have:      New(hgBin, "/mock/PARSE_BRANCH_FAIL"),
+			wantError: true,
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			got, err := tc.have.ParseVersionInfo()
+
+			if tc.wantError {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+				assert.Assert(t, tc.want.Equal(got), "want=%s; got=%s", tc.want, got)
+			}
+		})
+	}
+}
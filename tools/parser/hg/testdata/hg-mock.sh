@@ -0,0 +1,97 @@
+#!/bin/sh -eu
+
+mock_not_hg_repo() {
+  echo "abort: no repository found in '$PWD' (.hg not found)" >&2
+  exit 255
+}
+
+mock_root() {
+  echo "/mock/src"
+}
+
+mock_parse() {
+  case "$2" in
+    "branch")
+      if test "$1" = "BRANCH_FAIL"; then
+        echo "abort: repository is unrelated" >&2
+        exit 255
+      else
+        echo "test_mock"
+      fi
+      ;;
+    "log -r . --template {node}")
+      if test "$1" = "REV_FAIL"; then
+        echo "abort: unknown revision '.'" >&2
+        exit 255
+      else
+        echo "deadbeefcafe"
+      fi
+      ;;
+    "status --modified --added --removed --deleted")
+      if test "$1" = "DIRTY"; then
+        echo "M README.md"
+      fi
+      ;;
+    "status")
+      if test "$1" = "DIRTY"; then
+        echo "M README.md"
+      fi
+      if test "$1" = "DIRTY" || test "$1" = "UNTRACKED"; then
+        echo "? version.go"
+      fi
+      ;;
+    "log -r . --template {word(0, date|hgdate)} {author|person}")
+      echo "1700000000 Gordon Bleux"
+      ;;
+    "log -r . --template {latesttag}")
+      if test "$1" = "NO_TAG"; then
+        echo "null"
+      elif test "$1" = "MULTI_TAG"; then
+        echo "v1.23.456:stable"
+      else
+        echo "v1.23.456"
+      fi
+      ;;
+    "log -r . --template {latesttagdistance}")
+      if test "$1" = "DIRTY"; then
+        echo "12"
+      else
+        echo "0"
+      fi
+      ;;
+    *)
+      echo "Invalid mock usage"; exit 1 ;;
+  esac
+}
+
+
+if test $# -lt 2; then
+  echo "Not enough arguments" >&2
+  exit 1
+fi
+
+if test "$1" != "--cwd"; then
+  echo "Missing working directory argument" >&2
+  exit 1
+fi
+
+MOCK_STRATEGY="$2"
+shift 2
+
+case "$MOCK_STRATEGY" in
+  /mock/NOT_HG_REPO) mock_not_hg_repo ;;
+  /mock/ROOT) mock_root ;;
+  /mock/PARSE_ALL) mock_parse "OK" "$*" ;;
+  /mock/PARSE_NO_TAG) mock_parse "NO_TAG" "$*" ;;
+  /mock/PARSE_MULTI_TAG) mock_parse "MULTI_TAG" "$*" ;;
+  /mock/PARSE_REV_FAIL) mock_parse "REV_FAIL" "$*" ;;
+  /mock/PARSE_BRANCH_FAIL) mock_parse "BRANCH_FAIL" "$*" ;;
+  /mock/PARSE_DIRTY) mock_parse "DIRTY" "$*" ;;
+  /mock/PARSE_UNTRACKED) mock_parse "UNTRACKED" "$*" ;;
+  *)
+    echo "Invalid mock strategy $MOCK_STRATEGY" >&2
+    exit 1
+    ;;
+esac
+
+:
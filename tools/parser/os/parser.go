@@ -11,7 +11,7 @@ import (
 
 const (
 	// Static value to use for reproducible builds
-	ReproduciblePlaceholder = "reproducible"
+	ReproduciblePlaceholder = buildinfo.ReproduciblePlaceholder
 )
 
 // parser.EnvironmentParser implementation which retrieves information using
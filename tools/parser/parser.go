@@ -9,10 +9,13 @@ import (
 
 	"github.com/UiP9AV6Y/buildinfo"
 	"github.com/UiP9AV6Y/buildinfo/tools/parser/file"
+	"github.com/UiP9AV6Y/buildinfo/tools/parser/fossil"
 	"github.com/UiP9AV6Y/buildinfo/tools/parser/git"
+	"github.com/UiP9AV6Y/buildinfo/tools/parser/hg"
 	"github.com/UiP9AV6Y/buildinfo/tools/parser/mock"
 	"github.com/UiP9AV6Y/buildinfo/tools/parser/os"
 	"github.com/UiP9AV6Y/buildinfo/tools/parser/rpmspec"
+	"github.com/UiP9AV6Y/buildinfo/tools/parser/svn"
 )
 
 const (
@@ -63,6 +66,24 @@ func ParseVersionParser(dir string) (VersionParser, error) {
 		return nil, err
 	}
 
+	if h, err := hg.TrySystemParse(base); err == nil {
+		return h, nil
+	} else if !errors.Is(err, hg.ErrNoRepository) {
+		return nil, err
+	}
+
+	if s, err := svn.TrySystemParse(base); err == nil {
+		return s, nil
+	} else if !errors.Is(err, svn.ErrNoRepository) {
+		return nil, err
+	}
+
+	if f, err := fossil.TrySystemParse(base); err == nil {
+		return f, nil
+	} else if !errors.Is(err, fossil.ErrNoRepository) {
+		return nil, err
+	}
+
 	return nil, fmt.Errorf("Unable to detect version control system in %q", base)
 }
 
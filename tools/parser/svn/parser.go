@@ -0,0 +1,203 @@
+package svn
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/UiP9AV6Y/buildinfo"
+	"github.com/UiP9AV6Y/buildinfo/tools/util"
+)
+
+const (
+	systemSvn = "svn"
+	// svn error message when info yields no result
+	errParse = "is not a working copy"
+	// repository layout directories
+	layoutTrunk    = "trunk"
+	layoutBranches = "branches"
+	layoutTags     = "tags"
+)
+
+// Error when trying to parse a directory that is not a Subversion working copy
+var ErrNoRepository = fs.ErrNotExist
+
+// info is the relevant subset of the `svn info --xml` output
+type info struct {
+	Entry struct {
+		URL         string `xml:"url"`
+		RelativeURL string `xml:"relative-url"`
+		Repository  struct {
+			Root string `xml:"root"`
+		} `xml:"repository"`
+		WCInfo struct {
+			Root string `xml:"wcroot-abspath"`
+		} `xml:"wc-info"`
+		Commit struct {
+			Author string `xml:"author"`
+			Date   string `xml:"date"`
+		} `xml:"commit"`
+	} `xml:"entry"`
+}
+
+// path returns the location of the working copy inside the repository
+func (i *info) path() string {
+	if p := i.Entry.RelativeURL; p != "" {
+		return strings.TrimPrefix(p, "^")
+	}
+
+	return strings.TrimPrefix(i.Entry.URL, i.Entry.Repository.Root)
+}
+
+// parser.VersionParser implementation using Subversion as data backend
+type Svn struct {
+	cmd  string
+	root string
+}
+
+// TrySystemParse calls TryParse using the Subversion command found in the PATH
+func TrySystemParse(path string) (*Svn, error) {
+	return TryParse(systemSvn, path)
+}
+
+// TryParse attempts to parse the given directory as Subversion working copy
+// using the provided command.
+// If the given path does not seem to be a Subversion working copy,
+// ErrNoRepository is returned. All other errors are a result of file
+// access problems or data corruption issues.
+func TryParse(cmd, path string) (*Svn, error) {
+	realCmd, err := exec.LookPath(cmd)
+	if err != nil {
+		// might be a svn working copy, but we have no svn commandline client
+		return nil, ErrNoRepository
+	}
+
+	i, err := svnInfo(realCmd, path)
+	if err != nil {
+		if strings.Contains(err.Error(), errParse) {
+			// ignore the error type, as long as the error output
+			// contains hints about the failure cause
+			return nil, ErrNoRepository
+		}
+
+		return nil, err
+	}
+
+	return New(realCmd, i.Entry.WCInfo.Root), nil
+}
+
+// NewSystem creates a new parser.Parser instance using the provided
+// directory as project root. the svn executable is invoked as-is,
+// relying on its presence in one of the PATH directories.
+func NewSystem(root string) *Svn {
+	return New(systemSvn, root)
+}
+
+// New creates a new parser.Parser instance using the provided
+// directory as project root. the svn executable is invoked using
+// the provided path.
+func New(cmd, root string) *Svn {
+	result := &Svn{
+		cmd:  cmd,
+		root: root,
+	}
+
+	return result
+}
+
+// String implements the fmt.Stringer interface
+func (s *Svn) String() string {
+	return fmt.Sprintf("(cmd=%s, root=%s)", s.cmd, s.root)
+}
+
+// Equal compares the fields of this instance to the given one
+func (s *Svn) Equal(o *Svn) bool {
+	if o == nil {
+		return s == nil
+	}
+
+	return s.cmd == o.cmd && s.root == o.root
+}
+
+// ParseVersionInfo implements the parser.VersionParser interface.
+// The branch and version are derived from the conventional repository
+// layout: a working copy of trunk or branches/NAME results in the
+// respective branch, while a working copy of tags/NAME results in
+// NAME being used as version.
+func (s *Svn) ParseVersionInfo() (*buildinfo.VersionInfo, error) {
+	result := buildinfo.NewVersionInfo()
+
+	i, err := svnInfo(s.cmd, s.root)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to determine svn working copy details: %w", err)
+	}
+
+	// the working copy revision (BASE) changes with every update,
+	// even if the project itself has not been modified
+	revision, err := util.RunCmd(s.cmd, []string{"info", "--show-item", "last-changed-revision", s.root})
+	if err != nil {
+		return nil, fmt.Errorf("Unable to determine svn last changed revision: %w", err)
+	} else if revision == "" {
+		return nil, fmt.Errorf("Unable to determine svn last changed revision")
+	}
+	result.Revision = revision
+
+	// unversioned files and externals leave the working copy clean
+	status, err := util.RunCmd(s.cmd, []string{"status", "--ignore-externals", "-q", s.root})
+	if err != nil {
+		return nil, fmt.Errorf("Unable to determine svn working copy state: %w", err)
+	}
+	result.Dirty = status != ""
+
+	if d := i.Entry.Commit.Date; d != "" {
+		date, err := time.Parse(time.RFC3339Nano, d)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to parse svn commit date: %w", err)
+		}
+
+		date = date.UTC()
+		result.CommitDate = &date
+	}
+	result.CommitAuthor = i.Entry.Commit.Author
+
+	segments := strings.Split(strings.Trim(i.path(), "/"), "/")
+	for n, segment := range segments {
+		if segment == layoutTrunk {
+			result.Branch = layoutTrunk
+			break
+		}
+
+		if n+1 >= len(segments) {
+			break
+		}
+
+		if segment == layoutBranches {
+			result.Branch = segments[n+1]
+			break
+		}
+
+		if segment == layoutTags {
+			result.Version = buildinfo.TrimVersionPrefix(segments[n+1])
+			break
+		}
+	}
+
+	return result, nil
+}
+
+func svnInfo(cmd, path string) (*info, error) {
+	o, err := util.RunCmd(cmd, []string{"info", "--xml", path})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &info{}
+	if err := xml.Unmarshal([]byte(o), result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
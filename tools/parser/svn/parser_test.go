@@ -0,0 +1,182 @@
+package svn
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/UiP9AV6Y/buildinfo"
+)
+
+func mockSvnBin() (string, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	mockPath := wd + "/testdata"
+
+	os.Setenv("PATH", mockPath)
+
+	return mockPath + "/svn-mock.sh", nil
+}
+
+func TestTryParse(t *testing.T) {
+	type testCase struct {
+		haveCmd, havePath string
+		wantError         bool
+		want              *Svn
+	}
+
+	svnBin, err := mockSvnBin()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := map[string]testCase{
+		"not in PATH": {
+			haveCmd:   "svn-notexists",
+			wantError: true,
+		},
+		"no svn working copy": {
+			haveCmd:   "svn-mock.sh",
+			havePath:  "/mock/NOT_SVN_WC",
+			wantError: true,
+		},
+		"svn error": {
+			haveCmd:   "svn-mock.sh",
+			havePath:  "/mock/FAIL",
+			wantError: true,
+		},
+		"relative bin": {
+			haveCmd:  "svn-mock.sh",
+			havePath: "/mock/TRUNK",
+			want:     New(svnBin, "/mock/src"),
+		},
+		"absolute bin": {
+			haveCmd:  svnBin,
+			havePath: "/mock/TRUNK",
+			want:     New(svnBin, "/mock/src"),
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			got, err := TryParse(tc.haveCmd, tc.havePath)
+
+			if tc.wantError {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+				assert.Assert(t, tc.want.Equal(got), "want=%s; got=%s", tc.want, got)
+			}
+		})
+	}
+
+	_, err = TryParse("svn-mock.sh", "/mock/NOT_SVN_WC")
+	assert.ErrorIs(t, err, ErrNoRepository)
+}
+
+func TestParseVersionInfo(t *testing.T) {
+	type testCase struct {
+		have      *Svn
+		wantError bool
+		want      *buildinfo.VersionInfo
+	}
+
+	svnBin, err := mockSvnBin()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitDate := time.Unix(1700000000, 0)
+	testCases := map[string]testCase{
+		"trunk": {
+			have: New(svnBin, "/mock/TRUNK"),
+			want: &buildinfo.VersionInfo{
+				Version:      "0.0.0",
+				Revision:     "1200",
+				Branch:       "trunk",
+				CommitDate:   &commitDate,
+				CommitAuthor: "gbleux",
+			},
+		},
+		"branch": {
+			have: New(svnBin, "/mock/BRANCH"),
+			want: &buildinfo.VersionInfo{
+				Version:      "0.0.0",
+				Revision:     "1200",
+				Branch:       "feature-x",
+				CommitDate:   &commitDate,
+				CommitAuthor: "gbleux",
+			},
+		},
+		"tag": {
+			have: New(svnBin, "/mock/TAG"),
+			want: &buildinfo.VersionInfo{
+				Version:      "1.23.456",
+				Revision:     "1200",
+				Branch:       "trunk",
+				CommitDate:   &commitDate,
+				CommitAuthor: "gbleux",
+			},
+		},
+		"unknown layout": {
+			have: New(svnBin, "/mock/UNKNOWN"),
+			want: &buildinfo.VersionInfo{
+				Version:      "0.0.0",
+				Revision:     "1200",
+				Branch:       "trunk",
+				CommitDate:   &commitDate,
+				CommitAuthor: "gbleux",
+			},
+		},
+		"dirty": {
+			have: New(svnBin, "/mock/DIRTY"),
+			want: &buildinfo.VersionInfo{
+				Version:      "0.0.0",
+				Revision:     "1200",
+				Branch:       "trunk",
+				Dirty:        true,
+				CommitDate:   &commitDate,
+				CommitAuthor: "gbleux",
+			},
+		},
+		"unversioned": {
+			have: New(svnBin, "/mock/UNVERSIONED"),
+			want: &buildinfo.VersionInfo{
+				Version:      "0.0.0",
+				Revision:     "1200",
+				Branch:       "trunk",
+				CommitDate:   &commitDate,
+				CommitAuthor: "gbleux",
+			},
+		},
+		"no revision": {
+			have:      New(svnBin, "/mock/REV_FAIL"),
+			wantError: true,
+		},
+		"no info": {
+			have:      New(svnBin, "/mock/NOT_SVN_WC"),
+			wantError: true,
+		},
+		"no status": {
+			have:      New(svnBin, "/mock/STATUS_FAIL"),
+			wantError: true,
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			got, err := tc.have.ParseVersionInfo()
+
+			if tc.wantError {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+				assert.Assert(t, tc.want.Equal(got), "want=%s; got=%s", tc.want, got)
+			}
+		})
+	}
+}
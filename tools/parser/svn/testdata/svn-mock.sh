@@ -0,0 +1,94 @@
+#!/bin/sh -eu
+
+mock_not_svn_wc() {
+  echo "svn: warning: W155007: '$1' is not a working copy" >&2
+  echo "svn: E200009: Could not display info for all targets because some targets don't exist" >&2
+  exit 1
+}
+
+mock_info() {
+  printf '<?xml version="1.0" encoding="UTF-8"?>\n'
+  printf '<info>\n'
+  printf '<entry kind="dir" path="%s" revision="1234">\n' "$1"
+  printf '<url>https://svn.example.com/repo/project/%s</url>\n' "$2"
+  printf '<relative-url>^/project/%s</relative-url>\n' "$2"
+  printf '<repository>\n'
+  printf '<root>https://svn.example.com/repo</root>\n'
+  printf '<uuid>13f79535-47bb-0310-9956-ffa450edef68</uuid>\n'
+  printf '</repository>\n'
+  printf '<wc-info>\n'
+  printf '<wcroot-abspath>/mock/src</wcroot-abspath>\n'
+  printf '<schedule>normal</schedule>\n'
+  printf '<depth>infinity</depth>\n'
+  printf '</wc-info>\n'
+  printf '<commit revision="1200">\n'
+  printf '<author>gbleux</author>\n'
+  printf '<date>2023-11-14T22:13:20.000000Z</date>\n'
+  printf '</commit>\n'
+  printf '</entry>\n'
+  printf '</info>\n'
+}
+
+# quiet status output omits unversioned files,
+# externals are skipped altogether
+mock_status() {
+  if test "$1" = "DIRTY"; then
+    echo "M       README.md"
+  fi
+}
+
+mock_invalid_usage() {
+  echo "Invalid mock usage" >&2
+  exit 1
+}
+
+if test $# -lt 3; then
+  echo "Not enough arguments" >&2
+  exit 1
+fi
+
+case "$1 $2" in
+  "info --xml")
+    MOCK_CMD="info"
+    MOCK_STRATEGY="$3"
+    ;;
+  "info --show-item")
+    test "$3" = "last-changed-revision" || mock_invalid_usage
+    MOCK_CMD="revision"
+    MOCK_STRATEGY="$4"
+    ;;
+  "status --ignore-externals")
+    test "$3" = "-q" || mock_invalid_usage
+    MOCK_CMD="status"
+    MOCK_STRATEGY="$4"
+    ;;
+  *)
+    mock_invalid_usage
+    ;;
+esac
+
+case "$MOCK_CMD $MOCK_STRATEGY" in
+  "info /mock/NOT_SVN_WC") mock_not_svn_wc "$MOCK_STRATEGY" ;;
+  "info /mock/TRUNK") mock_info "$MOCK_STRATEGY" "trunk" ;;
+  "info /mock/BRANCH") mock_info "$MOCK_STRATEGY" "branches/feature-x" ;;
+  "info /mock/TAG") mock_info "$MOCK_STRATEGY" "tags/v1.23.456" ;;
+  "info /mock/UNKNOWN") mock_info "$MOCK_STRATEGY" "vendor/lib" ;;
+  "info /mock/DIRTY") mock_info "$MOCK_STRATEGY" "trunk/src" ;;
+  "info /mock/UNVERSIONED") mock_info "$MOCK_STRATEGY" "trunk" ;;
+  "info /mock/STATUS_FAIL") mock_info "$MOCK_STRATEGY" "trunk" ;;
+  "info /mock/REV_FAIL") mock_info "$MOCK_STRATEGY" "trunk" ;;
+  "revision /mock/REV_FAIL") ;;
+  revision*) echo "1200" ;;
+  "status /mock/STATUS_FAIL")
+    echo "svn: E155036: Please see the 'svn upgrade' command" >&2
+    exit 1
+    ;;
+  "status /mock/DIRTY") mock_status "DIRTY" ;;
+  status*) mock_status "CLEAN" ;;
+  *)
+    echo "Invalid mock strategy $MOCK_STRATEGY" >&2
+    exit 1
+    ;;
+esac
+
+:
@@ -0,0 +1,317 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+
+	"github.com/UiP9AV6Y/buildinfo"
+)
+
+const (
+	// schemaURL is the resource name used for compiling buildinfo.JSONSchema
+	schemaURL = "buildinfo.schema.json"
+	// keyword reporting unknown object members
+	additionalPropertiesKeyword = "/additionalProperties"
+)
+
+// quotedNameRe matches the single-quoted property names
+// listed in additionalProperties violation messages
+var quotedNameRe = regexp.MustCompile(`'((?:[^'\\]|\\.)*)'`)
+
+// Violation describes a location in the validated
+// data which does not conform to the schema.
+type Violation struct {
+	// Line is the 1-based line number of the offending value
+	Line int
+	// Column is the 1-based byte offset within the Line
+	Column int
+	// Pointer is the JSON Pointer (RFC 6901) of the offending value
+	Pointer string
+	// Message describes the violation
+	Message string
+}
+
+// String returns the violation in the form line:column: message
+func (v *Violation) String() string {
+	return fmt.Sprintf("%d:%d: %s", v.Line, v.Column, v.Message)
+}
+
+// Validator checks data against the buildinfo.JSONSchema
+type Validator struct {
+	schema *jsonschema.Schema
+}
+
+// New returns a Validator instance using the schema
+// provided by the buildinfo library.
+func New() (*Validator, error) {
+	c := jsonschema.NewCompiler()
+	c.Draft = jsonschema.Draft2020
+	c.AssertFormat = true
+
+	if err := c.AddResource(schemaURL, bytes.NewReader(buildinfo.JSONSchema())); err != nil {
+		return nil, err
+	}
+
+	s, err := c.Compile(schemaURL)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Validator{
+		schema: s,
+	}
+
+	return result, nil
+}
+
+// Validate checks the given data against the schema. Syntax errors
+// and schema violations are returned in order of their occurrence
+// within the data. An error is only returned if the validation
+// itself failed.
+func (v *Validator) Validate(data []byte) ([]*Violation, error) {
+	var doc interface{}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return syntaxViolation(data, err)
+	} else if _, err := dec.Token(); err != io.EOF {
+		return syntaxViolation(data, fmt.Errorf("invalid character after top-level value"))
+	}
+
+	err := v.schema.Validate(doc)
+	if err == nil {
+		return nil, nil
+	}
+
+	var verr *jsonschema.ValidationError
+	if !errors.As(err, &verr) {
+		return nil, err
+	}
+
+	locs, err := newLocations(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Violation
+	for _, leaf := range leafErrors(verr, nil) {
+		result = append(result, locs.violations(data, leaf)...)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Line != result[j].Line {
+			return result[i].Line < result[j].Line
+		}
+
+		return result[i].Column < result[j].Column
+	})
+
+	return result, nil
+}
+
+func syntaxViolation(data []byte, err error) ([]*Violation, error) {
+	var offset int64
+
+	var serr *json.SyntaxError
+	if errors.As(err, &serr) {
+		// the offset is located after the offending character
+		offset = serr.Offset - 1
+	} else if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		offset = int64(len(data))
+		err = errors.New("unexpected end of JSON input")
+	} else {
+		offset = int64(len(bytes.TrimRight(data, " \t\r\n")))
+	}
+
+	line, col := position(data, offset)
+	result := []*Violation{
+		{
+			Line:    line,
+			Column:  col,
+			Message: err.Error(),
+		},
+	}
+
+	return result, nil
+}
+
+// leafErrors flattens the validation error hierarchy
+// into the errors without further causes.
+func leafErrors(err *jsonschema.ValidationError, leafs []*jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(err.Causes) == 0 {
+		return append(leafs, err)
+	}
+
+	for _, c := range err.Causes {
+		leafs = append(leafs, leafErrors(c, nil)...)
+	}
+
+	return leafs
+}
+
+func pointerMessage(ptr, msg string) string {
+	if ptr == "" {
+		return msg
+	}
+
+	return ptr + ": " + msg
+}
+
+// unquoteNames extracts the property names
+// from an additionalProperties violation message.
+func unquoteNames(msg string) []string {
+	matches := quotedNameRe.FindAllStringSubmatch(msg, -1)
+	result := make([]string, 0, len(matches))
+	for _, m := range matches {
+		name := strings.ReplaceAll(m[1], `\'`, `'`)
+		name = strings.ReplaceAll(name, `"`, `\"`)
+		if n, err := strconv.Unquote(`"` + name + `"`); err == nil {
+			name = n
+		}
+
+		result = append(result, name)
+	}
+
+	return result
+}
+
+// position converts the given byte offset into
+// a 1-based line and column number.
+func position(data []byte, offset int64) (line, col int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	} else if offset < 0 {
+		offset = 0
+	}
+
+	head := data[:offset]
+	line = bytes.Count(head, []byte{'\n'}) + 1
+	col = len(head) - bytes.LastIndexByte(head, '\n')
+
+	return
+}
+
+// locations maps the JSON Pointer of every value in the
+// given data to the byte offset the value starts at. Object
+// members are additionally mapped to the offset of their key.
+type locations struct {
+	values map[string]int64
+	keys   map[string]int64
+}
+
+func newLocations(data []byte) (*locations, error) {
+	result := &locations{
+		values: map[string]int64{},
+		keys:   map[string]int64{},
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	if err := result.walk(dec, data, ""); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// violations converts the given validation error. Unknown object
+// members are reported individually at the location of their key.
+func (l *locations) violations(data []byte, err *jsonschema.ValidationError) []*Violation {
+	ptr := err.InstanceLocation
+	names := []string{}
+	if strings.HasSuffix(err.KeywordLocation, additionalPropertiesKeyword) {
+		names = unquoteNames(err.Message)
+	}
+
+	if len(names) == 0 {
+		line, col := position(data, l.values[ptr])
+		return []*Violation{
+			{
+				Line:    line,
+				Column:  col,
+				Pointer: ptr,
+				Message: pointerMessage(ptr, err.Message),
+			},
+		}
+	}
+
+	result := make([]*Violation, 0, len(names))
+	for _, n := range names {
+		p := ptr + "/" + escapePointer(n)
+		line, col := position(data, l.keys[p])
+		result = append(result, &Violation{
+			Line:    line,
+			Column:  col,
+			Pointer: p,
+			Message: pointerMessage(p, "unknown field"),
+		})
+	}
+
+	return result
+}
+
+func (l *locations) walk(dec *json.Decoder, data []byte, ptr string) error {
+	l.values[ptr] = valueStart(data, dec.InputOffset())
+
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		for dec.More() {
+			offset := valueStart(data, dec.InputOffset())
+			key, err := dec.Token()
+			if err != nil {
+				return err
+			}
+
+			p := ptr + "/" + escapePointer(key.(string))
+			l.keys[p] = offset
+			if err := l.walk(dec, data, p); err != nil {
+				return err
+			}
+		}
+
+		_, err = dec.Token()
+	case json.Delim('['):
+		for n := 0; dec.More(); n++ {
+			if err := l.walk(dec, data, ptr+"/"+strconv.Itoa(n)); err != nil {
+				return err
+			}
+		}
+
+		_, err = dec.Token()
+	}
+
+	return err
+}
+
+// valueStart skips any whitespace and separators
+// preceding a value starting at the given offset.
+func valueStart(data []byte, offset int64) int64 {
+	for offset < int64(len(data)) && strings.IndexByte(" \t\r\n:,", data[offset]) >= 0 {
+		offset++
+	}
+
+	return offset
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// escapePointer encodes a reference token the same
+// way the validation library does.
+func escapePointer(s string) string {
+	return url.PathEscape(pointerEscaper.Replace(s))
+}
@@ -0,0 +1,78 @@
+package validator
+
+import (
+	"os"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestValidate(t *testing.T) {
+	type testCase struct {
+		havePath string
+		want     []string
+	}
+
+	testCases := map[string]testCase{
+		"valid": {
+			havePath: "testdata/valid.json",
+		},
+		"empty": {
+			havePath: "testdata/empty.json",
+		},
+		"syntax": {
+			havePath: "testdata/syntax.json",
+			want: []string{
+				"3:16: invalid character 'n' in literal true (expecting 'e')",
+			},
+		},
+		"truncated": {
+			havePath: "testdata/truncated.json",
+			want: []string{
+				"3:1: unexpected end of JSON input",
+			},
+		},
+		"newer": {
+			havePath: "testdata/newer.json",
+			want: []string{
+				"1:12: /schema: must be <= 1 but found 2",
+			},
+		},
+		"invalid": {
+			havePath: "testdata/invalid.json",
+			want: []string{
+				"2:14: /version: expected string, but got number",
+				"3:12: /dirty: expected boolean, but got string",
+				"4:11: /date: 'yesterday' is not valid 'date-time'",
+				"6:24: /commits_since_tag: must be >= 0 but found -1",
+				"8:16: /extras/channel: expected string, but got number",
+				"12:7: /runtime/deps/0: missing properties: 'path'",
+				"12:29: /runtime/deps/0/other~1key: unknown field",
+			},
+		},
+	}
+
+	v, err := New()
+	assert.NilError(t, err)
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			data, err := os.ReadFile(tc.havePath)
+			assert.NilError(t, err)
+
+			got, err := v.Validate(data)
+			assert.NilError(t, err)
+
+			gotStrings := make([]string, len(got))
+			for i, g := range got {
+				gotStrings[i] = g.String()
+			}
+
+			if tc.want == nil {
+				tc.want = []string{}
+			}
+
+			assert.DeepEqual(t, gotStrings, tc.want)
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package buildinfo
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 )
 
 const (
@@ -22,6 +25,15 @@ type VersionInfo struct {
 	Version  string `json:"version,omitempty"`
 	Revision string `json:"revision,omitempty"`
 	Branch   string `json:"branch,omitempty"`
+	// Dirty indicates uncommitted changes in the project
+	Dirty bool `json:"dirty,omitempty"`
+	// CommitDate is the timestamp of the Revision, if known
+	CommitDate *time.Time `json:"commit_date,omitempty"`
+	// CommitAuthor is the author of the Revision, if known
+	CommitAuthor string `json:"commit_author,omitempty"`
+	// CommitsSinceTag is the number of commits between
+	// the Revision and the tag the Version was derived from
+	CommitsSinceTag uint `json:"commits_since_tag,omitempty"`
 }
 
 // NewVersionInfo returns a VersionInfo instance with default values
@@ -38,10 +50,31 @@ func (i *VersionInfo) String() string {
 	return fmt.Sprintf("(version=%s, branch=%s, revision=%s)", i.Version, i.Branch, i.Revision)
 }
 
+// versionInfo has the same fields as VersionInfo,
+// without the custom (un)marshalling
+type versionInfo VersionInfo
+
+// MarshalJSON implements the json.Marshaler interface. It takes
+// precedence over MarshalText to retain the object representation.
+func (i *VersionInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal((*versionInfo)(i))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. It takes
+// precedence over UnmarshalText to retain the object representation.
+func (i *VersionInfo) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*versionInfo)(i))
+}
+
 // Clone creates an independant copy of itself.
 func (i *VersionInfo) Clone() *VersionInfo {
 	i2 := *i
 
+	if i.CommitDate != nil {
+		d := *i.CommitDate
+		i2.CommitDate = &d
+	}
+
 	return &i2
 }
 
@@ -51,7 +84,17 @@ func (i *VersionInfo) Equal(o *VersionInfo) bool {
 		return i == nil && o == nil
 	}
 
-	return i.Version == o.Version && i.Revision == o.Revision && i.Branch == o.Branch
+	return i.Version == o.Version && i.Revision == o.Revision && i.Branch == o.Branch &&
+		i.Dirty == o.Dirty && i.CommitAuthor == o.CommitAuthor && i.CommitsSinceTag == o.CommitsSinceTag &&
+		equalTime(i.CommitDate, o.CommitDate)
+}
+
+func equalTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return a.Equal(*b)
 }
 
 // ShortRevision returns the truncated Revision.
@@ -70,3 +113,46 @@ func (i *VersionInfo) ShortRevision() string {
 func (i *VersionInfo) VersionRevision() string {
 	return i.Version + versionConcat + i.ShortRevision()
 }
+
+// SemVer parses the Version value as semantic version.
+// The returned error wraps ErrInvalidSemVer if the value
+// does not adhere to the specification. Note that
+// DefaultVersion is a valid semantic version (0.0.0),
+// denoting a project without any release information.
+func (i *VersionInfo) SemVer() (*SemVer, error) {
+	return ParseSemVer(i.Version)
+}
+
+// Compare returns an integer comparing the Version values of
+// this instance and the given one. The result is 0 if i == o,
+// -1 if i < o, and +1 if i > o. Semantic versions are compared
+// according to their precedence; values which are not semantic
+// versions are considered lower than any semantic version and
+// are compared lexically amongst themselves. As DefaultVersion
+// is equivalent to 0.0.0, it compares lower than any release.
+func (i *VersionInfo) Compare(o *VersionInfo) int {
+	if i == nil || o == nil {
+		switch {
+		case i != nil:
+			return 1
+		case o != nil:
+			return -1
+		default:
+			return 0
+		}
+	}
+
+	iv, iErr := i.SemVer()
+	ov, oErr := o.SemVer()
+
+	switch {
+	case iErr == nil && oErr == nil:
+		return iv.Compare(ov)
+	case iErr == nil:
+		return 1
+	case oErr == nil:
+		return -1
+	default:
+		return strings.Compare(i.Version, o.Version)
+	}
+}
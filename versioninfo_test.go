@@ -1,25 +1,71 @@
 package buildinfo
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
 	"gotest.tools/v3/assert"
 )
 
 func TestVersionInfoClone(t *testing.T) {
+	commitDate := time.Unix(4, 0)
 	have := &VersionInfo{
-		Version:  "1",
-		Revision: "2",
-		Branch:   "3",
+		Version:    "1",
+		Revision:   "2",
+		Branch:     "3",
+		CommitDate: &commitDate,
 	}
 	got := have.Clone()
 	got.Version = "01"
 	got.Revision = "02"
 	got.Branch = "03"
+	*got.CommitDate = time.Unix(5, 0)
 
 	assert.Assert(t, got.Version != have.Version)
 	assert.Assert(t, got.Revision != have.Revision)
 	assert.Assert(t, got.Branch != have.Branch)
+	assert.Assert(t, !got.CommitDate.Equal(*have.CommitDate))
+}
+
+func TestVersionInfoJSON(t *testing.T) {
+	type testCase struct {
+		have *VersionInfo
+		want string
+	}
+
+	commitDate := time.Unix(4, 0).UTC()
+	testCases := map[string]testCase{
+		"default": {
+			have: NewVersionInfo(),
+			want: `{"version":"0.0.0","revision":"HEAD","branch":"trunk"}`,
+		},
+		"full": {
+			have: &VersionInfo{
+				Version:         "1",
+				Revision:        "2",
+				Branch:          "3",
+				Dirty:           true,
+				CommitDate:      &commitDate,
+				CommitAuthor:    "5",
+				CommitsSinceTag: 6,
+			},
+			want: `{"version":"1","revision":"2","branch":"3","dirty":true,` +
+				`"commit_date":"1970-01-01T00:00:04Z","commit_author":"5","commits_since_tag":6}`,
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			got, err := json.Marshal(tc.have)
+			assert.Assert(t, err)
+			assert.Equal(t, string(got), tc.want)
+
+			parsed := &VersionInfo{}
+			assert.Assert(t, json.Unmarshal(got, parsed))
+			assert.Assert(t, tc.have.Equal(parsed), "want=%s; got=%s", tc.have, parsed)
+		})
+	}
 }
 
 func TestVersionInfoEqual(t *testing.T) {
@@ -28,6 +74,9 @@ func TestVersionInfoEqual(t *testing.T) {
 		want                bool
 	}
 
+	commitDate := time.Unix(4, 0)
+	commitDateUTC := commitDate.UTC()
+
 	testCases := map[string]testCase{
 		"nil": {
 			want: true,
@@ -100,6 +149,54 @@ func TestVersionInfoEqual(t *testing.T) {
 				Branch:   "0",
 			},
 		},
+		"dirty mismatch": {
+			haveLeft: &VersionInfo{
+				Version: "1",
+				Dirty:   true,
+			},
+			haveRight: &VersionInfo{
+				Version: "1",
+			},
+		},
+		"commit date": {
+			haveLeft: &VersionInfo{
+				Version:    "1",
+				CommitDate: &commitDate,
+			},
+			haveRight: &VersionInfo{
+				Version:    "1",
+				CommitDate: &commitDateUTC,
+			},
+			want: true,
+		},
+		"commit date mismatch": {
+			haveLeft: &VersionInfo{
+				Version:    "1",
+				CommitDate: &commitDate,
+			},
+			haveRight: &VersionInfo{
+				Version: "1",
+			},
+		},
+		"commit author mismatch": {
+			haveLeft: &VersionInfo{
+				Version:      "1",
+				CommitAuthor: "1",
+			},
+			haveRight: &VersionInfo{
+				Version:      "1",
+				CommitAuthor: "2",
+			},
+		},
+		"commits since tag mismatch": {
+			haveLeft: &VersionInfo{
+				Version:         "1",
+				CommitsSinceTag: 1,
+			},
+			haveRight: &VersionInfo{
+				Version: "1",
+			},
+		},
 	}
 
 	for ctx, tc := range testCases {
@@ -149,3 +246,62 @@ func TestVersionInfoShortRevision(t *testing.T) {
 		})
 	}
 }
+
+func TestVersionInfoCompare(t *testing.T) {
+	type testCase struct {
+		haveLeft, haveRight *VersionInfo
+		want                int
+	}
+
+	testCases := map[string]testCase{
+		"nil": {
+			want: 0,
+		},
+		"left nil": {
+			haveRight: NewVersionInfo(),
+			want:      -1,
+		},
+		"right nil": {
+			haveLeft: NewVersionInfo(),
+			want:     1,
+		},
+		"default": {
+			haveLeft:  NewVersionInfo(),
+			haveRight: NewVersionInfo(),
+			want:      0,
+		},
+		"default lower than release": {
+			haveLeft:  NewVersionInfo(),
+			haveRight: &VersionInfo{Version: "0.0.1"},
+			want:      -1,
+		},
+		"prefixed": {
+			haveLeft:  &VersionInfo{Version: "v1.4.0"},
+			haveRight: &VersionInfo{Version: "1.4.0"},
+			want:      0,
+		},
+		"newer": {
+			haveLeft:  &VersionInfo{Version: "1.10.0"},
+			haveRight: &VersionInfo{Version: "1.4.0"},
+			want:      1,
+		},
+		"semver over opaque": {
+			haveLeft:  &VersionInfo{Version: "20240101"},
+			haveRight: NewVersionInfo(),
+			want:      -1,
+		},
+		"opaque": {
+			haveLeft:  &VersionInfo{Version: "1.0"},
+			haveRight: &VersionInfo{Version: "1.1"},
+			want:      -1,
+		},
+	}
+
+	for ctx, tc := range testCases {
+		t.Run(ctx, func(t *testing.T) {
+			got := tc.haveLeft.Compare(tc.haveRight)
+
+			assert.Equal(t, got, tc.want)
+		})
+	}
+}